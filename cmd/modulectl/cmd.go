@@ -2,12 +2,14 @@ package modulectl
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/spf13/cobra"
 
 	createcmd "github.com/kyma-project/modulectl/cmd/modulectl/create"
 	scaffoldcmd "github.com/kyma-project/modulectl/cmd/modulectl/scaffold"
 	"github.com/kyma-project/modulectl/cmd/modulectl/version"
+	"github.com/kyma-project/modulectl/internal/service/checksum"
 	"github.com/kyma-project/modulectl/internal/service/componentconstructor"
 	"github.com/kyma-project/modulectl/internal/service/componentdescriptor"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
@@ -20,10 +22,15 @@ import (
 	"github.com/kyma-project/modulectl/internal/service/manifestparser"
 	moduleconfiggenerator "github.com/kyma-project/modulectl/internal/service/moduleconfig/generator"
 	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/oci"
 	"github.com/kyma-project/modulectl/internal/service/scaffold"
 	"github.com/kyma-project/modulectl/internal/service/templategenerator"
+	"github.com/kyma-project/modulectl/internal/service/variables"
 	"github.com/kyma-project/modulectl/internal/service/verifier"
+	"github.com/kyma-project/modulectl/internal/service/workdir"
+	"github.com/kyma-project/modulectl/tools/download"
 	"github.com/kyma-project/modulectl/tools/filesystem"
+	"github.com/kyma-project/modulectl/tools/httpauth"
 	"github.com/kyma-project/modulectl/tools/yaml"
 
 	_ "embed"
@@ -86,12 +93,25 @@ func NewCmd() (*cobra.Command, error) {
 
 func buildModuleService() (*create.Service, error) {
 	fileSystemUtil := &filesystem.Helper{}
-	tmpFileSystem := filesystem.NewTempFileSystem()
+	credentials := httpauth.NewCredentials()
+	httpClient := &http.Client{Transport: httpauth.NewTransport(http.DefaultTransport, credentials)}
+	downloader := download.NewDownloader(httpClient)
+	if cacheDir, err := download.DefaultCacheDir(); err == nil {
+		downloader = downloader.WithCacheDir(cacheDir)
+	}
+	tmpFileSystem := filesystem.NewTempFileSystem().WithDownloader(downloader)
+
+	ociFetcher := oci.NewFetcher().WithCredentials(credentials).WithDownloadSettings(downloader)
+	componentVerifier := oci.NewComponentVerifier().WithCredentials(credentials).WithDownloadSettings(downloader)
+	gitFetcher := git.NewFetcher().WithCredentials(credentials).WithDownloadSettings(downloader)
 
 	manifestFileResolver, err := fileresolver.NewFileResolver("kyma-module-manifest-*.yaml", tmpFileSystem)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create manifest file resolver: %w", err)
 	}
+	manifestFileResolver = manifestFileResolver.
+		WithFetcher(contentprovider.SchemeOCI, ociFetcher).
+		WithFetcher(contentprovider.SchemeGitHTTPS, gitFetcher)
 	manifestParser := manifestparser.NewService()
 	manifestService, err := contentprovider.NewManifest(manifestParser)
 	if err != nil {
@@ -102,8 +122,24 @@ func buildModuleService() (*create.Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create default CR file resolver: %w", err)
 	}
+	defaultCRFileResolver = defaultCRFileResolver.
+		WithFetcher(contentprovider.SchemeOCI, ociFetcher).
+		WithFetcher(contentprovider.SchemeGitHTTPS, gitFetcher)
+
+	resourceFileResolver, err := fileresolver.NewFileResolver("kyma-module-resource-*", tmpFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource file resolver: %w", err)
+	}
+	resourceFileResolver = resourceFileResolver.
+		WithFetcher(contentprovider.SchemeOCI, ociFetcher).
+		WithFetcher(contentprovider.SchemeGitHTTPS, gitFetcher)
+
+	moduleConfigFileResolver, err := fileresolver.NewFileResolver("kyma-module-config-*.yaml", tmpFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create module config file resolver: %w", err)
+	}
 
-	moduleConfigService, err := moduleconfigreader.NewService(fileSystemUtil)
+	moduleConfigService, err := moduleconfigreader.NewService(fileSystemUtil, moduleConfigFileResolver)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create module config service: %w", err)
 	}
@@ -125,11 +161,24 @@ func buildModuleService() (*create.Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create crd parser service: %w", err)
 	}
+	variableService, err := variables.NewService(fileSystemUtil, tmpFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create variable service: %w", err)
+	}
+	checksumService, err := checksum.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checksum service: %w", err)
+	}
+	workDirService, err := workdir.NewService(tmpFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work dir service: %w", err)
+	}
 	moduleService, err := create.NewService(moduleConfigService, gitSourcesService,
 		componentConstructorService,
 		moduleTemplateService,
-		crdParserService, imageVersionVerifierService, manifestService, manifestFileResolver,
-		defaultCRFileResolver, fileSystemUtil)
+		crdParserService, imageVersionVerifierService, imageVersionVerifierService, manifestService, manifestFileResolver,
+		defaultCRFileResolver, resourceFileResolver, fileSystemUtil, gitService, variableService,
+		checksumService, downloader, workDirService, componentVerifier)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create module service: %w", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,6 +55,28 @@ func Test_Execute_ParsesAllModuleOptions(t *testing.T) {
 		"--config-file", moduleConfigFile,
 		"--output", templateOutput,
 		"--output-constructor-file", constructorFile,
+		"--strict-associated-resources",
+		"--expand-variables",
+		"--strict-variables",
+		"--set", "MODULE_VERSION=1.0.0",
+		"--set", "REGISTRY=europe-docker.pkg.dev",
+		"--print-effective-config",
+		"--profile", "prod",
+		"--allow-unknown-fields",
+		"--lock-file", "module-config.lock",
+		"--offline",
+		"--download-timeout", "1m",
+		"--download-retries", "5",
+		"--work-dir", "work",
+		"--keep-work-dir",
+		"--constructor-paths", "bundle",
+		"--release",
+		"--allow-dirty",
+		"--require-version-tag",
+		"--version-from-tag",
+		"--no-git-source",
+		"--source-commit", "0123456789abcdef0123456789abcdef01234567",
+		"--reference-registry", "europe-docker.pkg.dev/kyma-project/prod",
 	}
 
 	svc := &moduleServiceStub{}
@@ -65,6 +88,27 @@ func Test_Execute_ParsesAllModuleOptions(t *testing.T) {
 	assert.Equal(t, moduleConfigFile, svc.opts.ConfigFile)
 	assert.Equal(t, templateOutput, svc.opts.TemplateOutput)
 	assert.Equal(t, constructorFile, svc.opts.OutputConstructorFile)
+	assert.True(t, svc.opts.StrictAssociatedResources)
+	assert.True(t, svc.opts.ExpandVariables)
+	assert.True(t, svc.opts.StrictVariables)
+	assert.Equal(t, []string{"MODULE_VERSION=1.0.0", "REGISTRY=europe-docker.pkg.dev"}, svc.opts.Variables)
+	assert.True(t, svc.opts.PrintEffectiveConfig)
+	assert.Equal(t, "prod", svc.opts.Profile)
+	assert.True(t, svc.opts.AllowUnknownFields)
+	assert.Equal(t, "module-config.lock", svc.opts.LockFile)
+	assert.True(t, svc.opts.Offline)
+	assert.Equal(t, time.Minute, svc.opts.DownloadTimeout)
+	assert.Equal(t, 5, svc.opts.DownloadRetries)
+	assert.Equal(t, "work", svc.opts.WorkDir)
+	assert.True(t, svc.opts.KeepWorkDir)
+	assert.Equal(t, "bundle", svc.opts.ConstructorPaths)
+	assert.True(t, svc.opts.Release)
+	assert.True(t, svc.opts.AllowDirty)
+	assert.True(t, svc.opts.RequireVersionTag)
+	assert.True(t, svc.opts.VersionFromTag)
+	assert.True(t, svc.opts.NoGitSource)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", svc.opts.SourceCommit)
+	assert.Equal(t, "europe-docker.pkg.dev/kyma-project/prod", svc.opts.ReferenceRegistry)
 }
 
 func Test_Execute_ParsesModuleShortOptions(t *testing.T) {
@@ -101,6 +145,27 @@ func Test_Execute_ModuleParsesDefaults(t *testing.T) {
 	assert.Equal(t, createcmd.ConfigFileFlagDefault, svc.opts.ConfigFile)
 	assert.Equal(t, createcmd.TemplateOutputFlagDefault, svc.opts.TemplateOutput)
 	assert.Equal(t, createcmd.OutputConstructorFileFlagDefault, svc.opts.OutputConstructorFile)
+	assert.Equal(t, createcmd.StrictAssociatedResourcesFlagDefault, svc.opts.StrictAssociatedResources)
+	assert.Equal(t, createcmd.ExpandVariablesFlagDefault, svc.opts.ExpandVariables)
+	assert.Equal(t, createcmd.StrictVariablesFlagDefault, svc.opts.StrictVariables)
+	assert.Empty(t, svc.opts.Variables)
+	assert.Equal(t, createcmd.PrintEffectiveConfigFlagDefault, svc.opts.PrintEffectiveConfig)
+	assert.Equal(t, createcmd.ProfileFlagDefault, svc.opts.Profile)
+	assert.Equal(t, createcmd.AllowUnknownFieldsFlagDefault, svc.opts.AllowUnknownFields)
+	assert.Equal(t, createcmd.LockFileFlagDefault, svc.opts.LockFile)
+	assert.Equal(t, createcmd.OfflineFlagDefault, svc.opts.Offline)
+	assert.Equal(t, createcmd.DownloadTimeoutFlagDefault, svc.opts.DownloadTimeout)
+	assert.Equal(t, createcmd.DownloadRetriesFlagDefault, svc.opts.DownloadRetries)
+	assert.Equal(t, createcmd.WorkDirFlagDefault, svc.opts.WorkDir)
+	assert.Equal(t, createcmd.KeepWorkDirFlagDefault, svc.opts.KeepWorkDir)
+	assert.Equal(t, createcmd.ConstructorPathsFlagDefault, svc.opts.ConstructorPaths)
+	assert.Equal(t, createcmd.ReleaseFlagDefault, svc.opts.Release)
+	assert.Equal(t, createcmd.AllowDirtyFlagDefault, svc.opts.AllowDirty)
+	assert.Equal(t, createcmd.RequireVersionTagFlagDefault, svc.opts.RequireVersionTag)
+	assert.Equal(t, createcmd.VersionFromTagFlagDefault, svc.opts.VersionFromTag)
+	assert.Equal(t, createcmd.NoGitSourceFlagDefault, svc.opts.NoGitSource)
+	assert.Equal(t, createcmd.SourceCommitFlagDefault, svc.opts.SourceCommit)
+	assert.Equal(t, createcmd.ReferenceRegistryFlagDefault, svc.opts.ReferenceRegistry)
 }
 
 // Test Stubs
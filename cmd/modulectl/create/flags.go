@@ -4,13 +4,14 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/kyma-project/modulectl/internal/service/create"
+	"github.com/kyma-project/modulectl/tools/download"
 )
 
 const (
 	ConfigFileFlagName    = "config-file"
 	configFileFlagShort   = "c"
 	ConfigFileFlagDefault = "module-config.yaml"
-	configFileFlagUsage   = "Specifies the path to the module configuration file."
+	configFileFlagUsage   = "Specifies the path to the module configuration file in YAML or JSON format. Use \"-\" to read it from the standard input."
 
 	TemplateOutputFlagName    = "output"
 	templateOutputFlagShort   = "o"
@@ -19,11 +20,94 @@ const (
 
 	ModuleSourcesGitDirectoryFlagName    = "module-sources-git-directory"
 	ModuleSourcesGitDirectoryFlagDefault = "."
-	ModuleSourcesGitDirectoryFlagUsage   = "Path to the directory containing the module sources. If not set, the current directory is used. The directory must contain a valid Git repository."
+	ModuleSourcesGitDirectoryFlagUsage   = "Path to the directory containing the module sources. If not set, the current directory is used. The directory must be located in a Git repository, for example, in a subdirectory of a monorepo, a linked worktree, or a submodule."
 
 	OutputConstructorFileFlagName    = "output-constructor-file"
 	OutputConstructorFileFlagDefault = "component-constructor.yaml"
 	OutputConstructorFileFlagUsage   = "Path to write the component constructor file to (default \"component-constructor.yaml\")."
+
+	StrictAssociatedResourcesFlagName    = "strict-associated-resources"
+	StrictAssociatedResourcesFlagDefault = false
+	strictAssociatedResourcesFlagUsage   = "Fails instead of warning when an associated resource is neither defined by a CRD in the manifest nor a built-in Kubernetes kind."
+
+	ExpandVariablesFlagName    = "expand-variables"
+	ExpandVariablesFlagDefault = false
+	expandVariablesFlagUsage   = "Expands ${NAME} variables in the module config, manifest, and default CR files. Implicitly enabled when --set or --strict-variables is provided."
+
+	StrictVariablesFlagName    = "strict-variables"
+	StrictVariablesFlagDefault = false
+	strictVariablesFlagUsage   = "Fails when a referenced variable is not defined. Implies --expand-variables."
+
+	PrintEffectiveConfigFlagName    = "print-effective-config"
+	PrintEffectiveConfigFlagDefault = false
+	printEffectiveConfigFlagUsage   = "Prints the module config after merging all base configs referenced by the extends attribute and exits without creating any files."
+
+	ProfileFlagName    = "profile"
+	ProfileFlagDefault = ""
+	profileFlagUsage   = "Name of the profile in the profiles section of the module config whose overlay is applied on top of the module config before validation."
+
+	AllowUnknownFieldsFlagName    = "allow-unknown-fields"
+	AllowUnknownFieldsFlagDefault = false
+	allowUnknownFieldsFlagUsage   = "Ignores attributes in the module config that are unknown to this version of modulectl instead of failing."
+
+	LockFileFlagName    = "lock-file"
+	LockFileFlagDefault = ""
+	lockFileFlagUsage   = "Path to a lock file recording the SHA-256 checksums of the remote manifest and default CR. Missing checksums are recorded on the first run; later runs fail if a downloaded file does not match its recorded checksum."
+
+	OfflineFlagName    = "offline"
+	OfflineFlagDefault = false
+	offlineFlagUsage   = "Uses only previously downloaded files from the download cache and fails if a remote file is not cached."
+
+	DownloadTimeoutFlagName    = "download-timeout"
+	DownloadTimeoutFlagDefault = download.DefaultTimeout
+	downloadTimeoutFlagUsage   = "Timeout of a single attempt to download a remote file."
+
+	DownloadRetriesFlagName    = "download-retries"
+	DownloadRetriesFlagDefault = download.DefaultRetries
+	downloadRetriesFlagUsage   = "Number of times the download of a remote file is retried after a network or server error, with exponential backoff."
+
+	WorkDirFlagName    = "work-dir"
+	WorkDirFlagDefault = ""
+	workDirFlagUsage   = "Path to the work directory for downloaded and generated files. Defaults to a new temporary directory, which is kept after a successful run if the component constructor references files in it."
+
+	KeepWorkDirFlagName    = "keep-work-dir"
+	KeepWorkDirFlagDefault = false
+	keepWorkDirFlagUsage   = "Keeps the work directory, including temporary files, after the command has finished."
+
+	ConstructorPathsFlagName    = "constructor-paths"
+	ConstructorPathsFlagDefault = create.ConstructorPathsAbsolute
+	constructorPathsFlagUsage   = "How the component constructor file references file inputs: 'absolute' for absolute paths, 'relative' for paths relative to the constructor file, or 'bundle' to copy the files to a '<constructor file name>-bundle' directory next to the constructor file and reference them by relative paths."
+
+	ReleaseFlagName    = "release"
+	ReleaseFlagDefault = false
+	releaseFlagUsage   = "Marks the build as a release build, which fails if tracked files in the module sources Git repository have uncommitted changes."
+
+	AllowDirtyFlagName    = "allow-dirty"
+	AllowDirtyFlagDefault = false
+	allowDirtyFlagUsage   = "Allows release builds from a module sources Git repository with uncommitted changes. The module sources are labeled as dirty."
+
+	RequireVersionTagFlagName    = "require-version-tag"
+	RequireVersionTagFlagDefault = false
+	requireVersionTagFlagUsage   = "Fails if the HEAD commit of the module sources Git repository is not tagged with the module version, with or without 'v' prefix."
+
+	VersionFromTagFlagName    = "version-from-tag"
+	VersionFromTagFlagDefault = false
+	versionFromTagFlagUsage   = "Uses the semantic version the HEAD commit of the module sources Git repository is tagged with, with or without 'v' prefix, as the module version instead of the version in the module config."
+
+	NoGitSourceFlagName    = "no-git-source"
+	NoGitSourceFlagDefault = false
+	noGitSourceFlagUsage   = "Builds the module without a module sources Git repository. No Git source is added to the component constructor and the repository must be set in the module config."
+
+	SourceCommitFlagName    = "source-commit"
+	SourceCommitFlagDefault = ""
+	sourceCommitFlagUsage   = "Adds a Git source with the given full commit hash instead of reading the commit from the module sources Git repository, for example, when building from an exported source archive."
+
+	ReferenceRegistryFlagName    = "reference-registry"
+	ReferenceRegistryFlagDefault = ""
+	referenceRegistryFlagUsage   = "OCI registry, for example, europe-docker.pkg.dev/kyma-project/prod, in which the versions of the components referenced in the module config must exist. If not set, the component references are not verified."
+
+	SetFlagName  = "set"
+	setFlagUsage = "Sets a variable used for expansion in the KEY=VALUE format. Takes precedence over environment variables and built-in variables. Can be repeated. Implies --expand-variables."
 )
 
 func parseFlags(flags *pflag.FlagSet, opts *create.Options) {
@@ -52,4 +136,90 @@ func parseFlags(flags *pflag.FlagSet, opts *create.Options) {
 		OutputConstructorFileFlagName,
 		OutputConstructorFileFlagDefault,
 		OutputConstructorFileFlagUsage)
+
+	flags.BoolVar(&opts.StrictAssociatedResources,
+		StrictAssociatedResourcesFlagName,
+		StrictAssociatedResourcesFlagDefault,
+		strictAssociatedResourcesFlagUsage)
+
+	flags.BoolVar(&opts.ExpandVariables,
+		ExpandVariablesFlagName,
+		ExpandVariablesFlagDefault,
+		expandVariablesFlagUsage)
+	flags.BoolVar(&opts.StrictVariables,
+		StrictVariablesFlagName,
+		StrictVariablesFlagDefault,
+		strictVariablesFlagUsage)
+	flags.StringArrayVar(&opts.Variables,
+		SetFlagName,
+		nil,
+		setFlagUsage)
+	flags.BoolVar(&opts.PrintEffectiveConfig,
+		PrintEffectiveConfigFlagName,
+		PrintEffectiveConfigFlagDefault,
+		printEffectiveConfigFlagUsage)
+	flags.StringVar(&opts.Profile,
+		ProfileFlagName,
+		ProfileFlagDefault,
+		profileFlagUsage)
+	flags.BoolVar(&opts.AllowUnknownFields,
+		AllowUnknownFieldsFlagName,
+		AllowUnknownFieldsFlagDefault,
+		allowUnknownFieldsFlagUsage)
+	flags.StringVar(&opts.LockFile,
+		LockFileFlagName,
+		LockFileFlagDefault,
+		lockFileFlagUsage)
+	flags.BoolVar(&opts.Offline,
+		OfflineFlagName,
+		OfflineFlagDefault,
+		offlineFlagUsage)
+	flags.DurationVar(&opts.DownloadTimeout,
+		DownloadTimeoutFlagName,
+		DownloadTimeoutFlagDefault,
+		downloadTimeoutFlagUsage)
+	flags.IntVar(&opts.DownloadRetries,
+		DownloadRetriesFlagName,
+		DownloadRetriesFlagDefault,
+		downloadRetriesFlagUsage)
+	flags.StringVar(&opts.WorkDir,
+		WorkDirFlagName,
+		WorkDirFlagDefault,
+		workDirFlagUsage)
+	flags.BoolVar(&opts.KeepWorkDir,
+		KeepWorkDirFlagName,
+		KeepWorkDirFlagDefault,
+		keepWorkDirFlagUsage)
+	flags.StringVar(&opts.ConstructorPaths,
+		ConstructorPathsFlagName,
+		ConstructorPathsFlagDefault,
+		constructorPathsFlagUsage)
+	flags.BoolVar(&opts.Release,
+		ReleaseFlagName,
+		ReleaseFlagDefault,
+		releaseFlagUsage)
+	flags.BoolVar(&opts.AllowDirty,
+		AllowDirtyFlagName,
+		AllowDirtyFlagDefault,
+		allowDirtyFlagUsage)
+	flags.BoolVar(&opts.RequireVersionTag,
+		RequireVersionTagFlagName,
+		RequireVersionTagFlagDefault,
+		requireVersionTagFlagUsage)
+	flags.BoolVar(&opts.VersionFromTag,
+		VersionFromTagFlagName,
+		VersionFromTagFlagDefault,
+		versionFromTagFlagUsage)
+	flags.BoolVar(&opts.NoGitSource,
+		NoGitSourceFlagName,
+		NoGitSourceFlagDefault,
+		noGitSourceFlagUsage)
+	flags.StringVar(&opts.SourceCommit,
+		SourceCommitFlagName,
+		SourceCommitFlagDefault,
+		sourceCommitFlagUsage)
+	flags.StringVar(&opts.ReferenceRegistry,
+		ReferenceRegistryFlagName,
+		ReferenceRegistryFlagDefault,
+		referenceRegistryFlagUsage)
 }
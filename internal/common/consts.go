@@ -9,6 +9,15 @@ const (
 	OCMIdentityName = "module-sources"
 	OCMVersion      = "v1"
 
+	DirtyLabelKey   = ProviderName + "/dirty"
+	DirtyLabelValue = "true"
+
+	GitBranchLabelKey          = ProviderName + "/git-branch"
+	GitTagsLabelKey            = ProviderName + "/git-tags"
+	GitCommitTimestampLabelKey = ProviderName + "/git-commit-timestamp"
+	GitSignatureTypeLabelKey   = ProviderName + "/git-signature-type"
+	GitSubdirectoryLabelKey    = ProviderName + "/git-subdirectory"
+
 	SecurityScanLabelKey      = "security.kyma-project.io/scan"
 	SecurityScanEnabledValue  = "enabled"
 	SecScanBaseLabelKey       = "scan.security.kyma-project.io"
@@ -18,6 +27,8 @@ const (
 	ResponsiblesLabelKey      = "cloud.gardener.cnudie/responsibles"
 	GitHubHostname            = "github.tools.sap"
 	ResponsibleTypeGitHubTeam = "githubTeam"
+	ResponsibleTypeGitHubUser = "githubUser"
+	ResponsibleTypeEmail      = "emailAddress"
 
 	ModuleImageResourceName    = "module-image"
 	RawManifestResourceName    = "raw-manifest"
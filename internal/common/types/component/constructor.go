@@ -2,6 +2,7 @@ package component
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -35,6 +36,7 @@ type Input struct {
 	Type         string   `yaml:"type"`
 	Path         string   `yaml:"path,omitempty"`
 	Data         string   `yaml:"data,omitempty"`
+	MediaType    string   `yaml:"mediaType,omitempty"`
 	Compress     bool     `yaml:"compress,omitempty"`
 	IncludeFiles []string `yaml:"includeFiles,omitempty"`
 }
@@ -57,11 +59,21 @@ type Label struct {
 //
 //nolint:tagliatelle // OCM spec requires snake_case field names
 type ResponsibleEntry struct {
-	GitHubHostname string `yaml:"github_hostname"`
-	TeamName       string `yaml:"teamname"`
+	GitHubHostname string `yaml:"github_hostname,omitempty"`
+	TeamName       string `yaml:"teamname,omitempty"`
+	Username       string `yaml:"username,omitempty"`
+	Email          string `yaml:"email,omitempty"`
 	Type           string `yaml:"type"`
 }
 
+// GitSignatureTypes describes the signature types of the git commit and tags a source was built from. The signatures
+// are not verified, the types only tell how the commit and tags claim to be signed, so they must not be used as an
+// audit or trust signal.
+type GitSignatureTypes struct {
+	Commit string            `yaml:"commit,omitempty"`
+	Tags   map[string]string `yaml:"tags,omitempty"`
+}
+
 type Resource struct {
 	Name     string  `yaml:"name"`
 	Type     string  `yaml:"type"`
@@ -81,13 +93,22 @@ type Source struct {
 	Access  *Access `yaml:"access,omitempty"`
 }
 
+// ComponentReference references another component version the component is composed of.
+type ComponentReference struct {
+	Name          string  `yaml:"name"`
+	ComponentName string  `yaml:"componentName"`
+	Version       string  `yaml:"version"`
+	Labels        []Label `yaml:"labels,omitempty"`
+}
+
 type Component struct {
-	Name      string     `yaml:"name"`
-	Version   string     `yaml:"version"`
-	Provider  Provider   `yaml:"provider"`
-	Labels    []Label    `yaml:"labels,omitempty"`
-	Resources []Resource `yaml:"resources"`
-	Sources   []Source   `yaml:"sources,omitempty"`
+	Name                string               `yaml:"name"`
+	Version             string               `yaml:"version"`
+	Provider            Provider             `yaml:"provider"`
+	Labels              []Label              `yaml:"labels,omitempty"`
+	Resources           []Resource           `yaml:"resources"`
+	Sources             []Source             `yaml:"sources,omitempty"`
+	ComponentReferences []ComponentReference `yaml:"componentReferences,omitempty"`
 }
 
 type Constructor struct {
@@ -129,6 +150,20 @@ func (c *Constructor) AddGitSource(gitRepoURL, commitHash string) {
 	c.Components[0].Sources = append(c.Components[0].Sources, source)
 }
 
+// AddSourceLabel adds a label to the source with the given name. It does nothing if there is no such source.
+func (c *Constructor) AddSourceLabel(sourceName, key string, value any, version string) {
+	for i, source := range c.Components[0].Sources {
+		if source.Name == sourceName {
+			c.Components[0].Sources[i].Labels = append(source.Labels, Label{
+				Name:    key,
+				Value:   value,
+				Version: version,
+			})
+			return
+		}
+	}
+}
+
 func (c *Constructor) AddLabel(key, value, version string) {
 	labels := c.Components[0].Labels
 	labelValue := Label{
@@ -175,6 +210,60 @@ func (c *Constructor) AddFileResource(resourceName, filePath string) error {
 	}
 }
 
+// AddCustomResource adds a resource of the given type whose content is read from the file or directory at filePath.
+// Directories are added as dir inputs and files as file inputs, the media type is optional. It fails if the component
+// already has a resource with the same name.
+func (c *Constructor) AddCustomResource(resourceName, resourceType, mediaType, filePath string) error {
+	for _, resource := range c.Components[0].Resources {
+		if resource.Name == resourceName {
+			return fmt.Errorf("resource %s already exists: %w", resourceName, commonerrors.ErrInvalidArg)
+		}
+	}
+
+	filePath, err := getAbsPath(filePath)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s resource: %w", resourceName, err)
+	}
+
+	input := &Input{
+		Type:      FileResourceInput,
+		Path:      filePath,
+		MediaType: mediaType,
+	}
+	if info.IsDir() {
+		input.Type = DirectoryInputType
+	}
+
+	c.Components[0].Resources = append(c.Components[0].Resources, Resource{
+		Name:    resourceName,
+		Type:    resourceType,
+		Version: c.Components[0].Version,
+		Input:   input,
+	})
+	return nil
+}
+
+// AddComponentReference adds a reference to the version of the component with the given name. It fails if the
+// component already has a reference with the same name.
+func (c *Constructor) AddComponentReference(referenceName, componentName, version string) error {
+	for _, reference := range c.Components[0].ComponentReferences {
+		if reference.Name == referenceName {
+			return fmt.Errorf("component reference %s already exists: %w", referenceName, commonerrors.ErrInvalidArg)
+		}
+	}
+
+	c.Components[0].ComponentReferences = append(c.Components[0].ComponentReferences, ComponentReference{
+		Name:          referenceName,
+		ComponentName: componentName,
+		Version:       version,
+	})
+	return nil
+}
+
 func (c *Constructor) addFileAsDirResource(resourceName, filePath string) error {
 	dir, err := getAbsPath(filepath.Dir(filePath))
 	if err != nil {
@@ -213,6 +302,27 @@ func (c *Constructor) addFileAsPlainTextResource(resourceName, filePath string)
 	return nil
 }
 
+// RelativizeInputPaths replaces the paths of all file and directory inputs with paths relative to baseDir, which
+// must be absolute. The OCM CLI resolves relative input paths against the directory of the constructor file.
+func (c *Constructor) RelativizeInputPaths(baseDir string) error {
+	for i := range c.Components[0].Resources {
+		input := c.Components[0].Resources[i].Input
+		if input == nil || input.Path == "" {
+			continue
+		}
+		path, err := getAbsPath(input.Path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to get path of %s relative to %s: %w", path, baseDir, err)
+		}
+		input.Path = filepath.ToSlash(relPath)
+	}
+	return nil
+}
+
 func getAbsPath(filePath string) (string, error) {
 	if !filepath.IsAbs(filePath) {
 		absPath, err := filepath.Abs(filePath)
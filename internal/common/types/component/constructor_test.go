@@ -1,12 +1,14 @@
 package component_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/kyma-project/modulectl/internal/common"
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/service/image"
 )
@@ -399,6 +401,22 @@ func TestConstructor_AddFileResource_RawManifest_ParentDirectory(t *testing.T) {
 	require.Equal(t, "manifest.yaml", resource.Input.IncludeFiles[0])
 }
 
+func TestConstructor_RelativizeInputPaths(t *testing.T) {
+	constructor := component.NewConstructor("test-component", "1.0.0")
+	require.NoError(t, constructor.AddFileResource(common.RawManifestResourceName, "/build/work/manifest.yaml"))
+	require.NoError(t, constructor.AddFileResource(common.ModuleTemplateResourceName, "/build/template.yaml"))
+	constructor.AddImageAsResource([]*image.ImageInfo{{Name: "nginx", Tag: "1.21.0", FullURL: "nginx:1.21.0"}})
+
+	err := constructor.RelativizeInputPaths("/build/out")
+
+	require.NoError(t, err)
+	resources := constructor.Components[0].Resources
+	require.Equal(t, "../work", resources[0].Input.Path)
+	require.Equal(t, []string{"manifest.yaml"}, resources[0].Input.IncludeFiles)
+	require.Equal(t, "../template.yaml", resources[1].Input.Path)
+	require.Nil(t, resources[2].Input)
+}
+
 func TestConstructor_AddLabel_WithStringValue(t *testing.T) {
 	constructor := component.NewConstructor("test-component", "1.0.0")
 
@@ -411,3 +429,75 @@ func TestConstructor_AddLabel_WithStringValue(t *testing.T) {
 	require.Equal(t, "string-label", label.Name)
 	require.Equal(t, "simple-string-value", label.Value)
 }
+
+func TestConstructor_AddCustomResource_AddsFileInput(t *testing.T) {
+	constructor := component.NewConstructor("test-component", "1.0.0")
+	filePath := filepath.Join(t.TempDir(), "dashboard.json")
+	require.NoError(t, os.WriteFile(filePath, []byte("{}"), 0o600))
+
+	err := constructor.AddCustomResource("dashboard", "grafanaDashboard", "application/json", filePath)
+
+	require.NoError(t, err)
+	require.Len(t, constructor.Components[0].Resources, 1)
+	resource := constructor.Components[0].Resources[0]
+	require.Equal(t, "dashboard", resource.Name)
+	require.Equal(t, "grafanaDashboard", resource.Type)
+	require.Equal(t, "1.0.0", resource.Version)
+	require.Equal(t, &component.Input{
+		Type:      component.FileResourceInput,
+		Path:      filePath,
+		MediaType: "application/json",
+	}, resource.Input)
+}
+
+func TestConstructor_AddCustomResource_AddsDirInput(t *testing.T) {
+	constructor := component.NewConstructor("test-component", "1.0.0")
+	dir := t.TempDir()
+
+	err := constructor.AddCustomResource("docs", "directoryTree", "", dir)
+
+	require.NoError(t, err)
+	require.Len(t, constructor.Components[0].Resources, 1)
+	require.Equal(t, &component.Input{Type: component.DirectoryInputType, Path: dir},
+		constructor.Components[0].Resources[0].Input)
+}
+
+func TestConstructor_AddCustomResource_ReturnsError_WhenResourceExists(t *testing.T) {
+	constructor := component.NewConstructor("test-component", "1.0.0")
+	dir := t.TempDir()
+	require.NoError(t, constructor.AddCustomResource("docs", "directoryTree", "", dir))
+
+	err := constructor.AddCustomResource("docs", "directoryTree", "", dir)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.ErrorContains(t, err, "resource docs already exists")
+}
+
+func TestConstructor_AddCustomResource_ReturnsError_WhenPathDoesNotExist(t *testing.T) {
+	constructor := component.NewConstructor("test-component", "1.0.0")
+
+	err := constructor.AddCustomResource("docs", "directoryTree", "", filepath.Join(t.TempDir(), "missing"))
+
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestConstructor_AddComponentReference(t *testing.T) {
+	constructor := component.NewConstructor("test-component", "1.0.0")
+
+	err := constructor.AddComponentReference("istio", "kyma-project.io/module/istio", "1.2.0")
+
+	require.NoError(t, err)
+	require.Equal(t, []component.ComponentReference{
+		{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+	}, constructor.Components[0].ComponentReferences)
+}
+
+func TestConstructor_AddComponentReference_ReturnsError_WhenReferenceExists(t *testing.T) {
+	constructor := component.NewConstructor("test-component", "1.0.0")
+	require.NoError(t, constructor.AddComponentReference("istio", "kyma-project.io/module/istio", "1.2.0"))
+
+	err := constructor.AddComponentReference("istio", "kyma-project.io/module/istio", "1.3.0")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.ErrorContains(t, err, "component reference istio already exists")
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -14,6 +16,16 @@ type ResourcePaths struct {
 	DefaultCR      string
 	RawManifest    string
 	ModuleTemplate string
+	// ComponentResources are the additional resources of the component, which reference local files or directories.
+	ComponentResources []ComponentResource
+}
+
+// ComponentResource is an additional resource of the component whose content is read from a local file or directory.
+type ComponentResource struct {
+	Name      string
+	Type      string
+	MediaType string
+	Path      string
 }
 
 func NewResourcePaths(defaultCRPath, rawManifestPath, moduleTemplatePath string) *ResourcePaths {
@@ -23,3 +35,20 @@ func NewResourcePaths(defaultCRPath, rawManifestPath, moduleTemplatePath string)
 		ModuleTemplate: moduleTemplatePath,
 	}
 }
+
+// GitMetadata describes the HEAD commit of a git repository.
+type GitMetadata struct {
+	// Subdirectory is the slash-separated path of the module sources relative to the repository root, empty if the
+	// module sources are the repository root.
+	Subdirectory string
+	// Branch is the branch HEAD points to, empty if HEAD is detached.
+	Branch string
+	// Tags are the sorted names of the tags pointing at the HEAD commit.
+	Tags       []string
+	CommitTime time.Time
+	// CommitSignatureType is the type of the unverified commit signature (gpg, ssh or x509), empty if the commit is
+	// not signed.
+	CommitSignatureType string
+	// TagSignatureTypes maps the names of signed annotated tags in Tags to the type of their unverified signature.
+	TagSignatureTypes map[string]string
+}
@@ -1,13 +1,17 @@
 package validation
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/mail"
 	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
 
+	"github.com/kyma-project/modulectl/internal/common"
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 )
 
@@ -17,6 +21,23 @@ const (
 	moduleNameMaxLength = 255
 	namespaceMaxLength  = 253
 	namespacePattern    = "^[a-z0-9]+(?:-[a-z0-9]+)*$"
+	labelNamePattern    = `^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+/[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$` //nolint:lll // for readability
+	labelVersionPattern = "^v[0-9]+$"
+	resourceNamePattern = "^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	hostnamePattern     = `^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+$`
+	gitHubUserPattern   = "^[A-Za-z0-9]([-A-Za-z0-9]*[A-Za-z0-9])?$"
+	gitHubTeamPattern   = "^[A-Za-z0-9]([-A-Za-z0-9]*[A-Za-z0-9])?/[A-Za-z0-9][-A-Za-z0-9_.]*$"
+)
+
+//nolint:gochecknoglobals // read-only compiled patterns
+var (
+	labelNameRegexp    = regexp.MustCompile(labelNamePattern)
+	labelVersionRegexp = regexp.MustCompile(labelVersionPattern)
+	resourceNameRegexp = regexp.MustCompile(resourceNamePattern)
+	componentRegexp    = regexp.MustCompile(moduleNamePattern)
+	hostnameRegexp     = regexp.MustCompile(hostnamePattern)
+	gitHubUserRegexp   = regexp.MustCompile(gitHubUserPattern)
+	gitHubTeamRegexp   = regexp.MustCompile(gitHubTeamPattern)
 )
 
 func ValidateModuleName(name string) error {
@@ -111,6 +132,159 @@ func ValidateIsValidHTTPSURL(input string) error {
 	return nil
 }
 
+// ValidateSHA256 validates that the checksum is a hex encoded SHA-256 checksum.
+func ValidateSHA256(checksum string) error {
+	if len(checksum) != sha256.Size*2 {
+		return fmt.Errorf("'%s' must be a SHA-256 checksum of %d hex characters: %w", checksum, sha256.Size*2,
+			commonerrors.ErrInvalidOption)
+	}
+
+	if _, err := hex.DecodeString(checksum); err != nil || strings.ToLower(checksum) != checksum {
+		return fmt.Errorf("'%s' must be a lowercase hex encoded SHA-256 checksum: %w", checksum,
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateLabelName validates that the OCM label name is qualified with a DNS domain, e.g. 'example.com/owner', and
+// is not reserved for the labels set by modulectl, which are qualified with the kyma-project.io domain.
+func ValidateLabelName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if !labelNameRegexp.MatchString(name) {
+		return fmt.Errorf("name '%s' must be qualified with a DNS domain, e.g. 'example.com/owner': %w", name,
+			commonerrors.ErrInvalidOption)
+	}
+
+	domain, _, _ := strings.Cut(name, "/")
+	if domain == common.ProviderName || strings.HasSuffix(domain, "."+common.ProviderName) ||
+		name == common.ResponsiblesLabelKey {
+		return fmt.Errorf("name '%s' is reserved for labels set by modulectl: %w", name,
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateLabelVersion validates the version of an OCM label, which is optional and must have the 'v1' format.
+func ValidateLabelVersion(version string) error {
+	if version != "" && !labelVersionRegexp.MatchString(version) {
+		return fmt.Errorf("version '%s' must have the format 'v<number>', e.g. 'v1': %w", version,
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateResourceName validates that the OCM resource name consists of lowercase alphanumeric characters and hyphens
+// and is not one of the resource names used by modulectl.
+func ValidateResourceName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if !resourceNameRegexp.MatchString(name) {
+		return fmt.Errorf("name '%s' must consist of lowercase alphanumeric characters and hyphens: %w", name,
+			commonerrors.ErrInvalidOption)
+	}
+
+	switch name {
+	case common.RawManifestResourceName, common.DefaultCRResourceName, common.ModuleTemplateResourceName,
+		common.ModuleImageResourceName:
+		return fmt.Errorf("name '%s' is reserved for resources created by modulectl: %w", name,
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateComponentReferenceName validates that the name of an OCM component reference consists of lowercase
+// alphanumeric characters and hyphens.
+func ValidateComponentReferenceName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if !resourceNameRegexp.MatchString(name) {
+		return fmt.Errorf("name '%s' must consist of lowercase alphanumeric characters and hyphens: %w", name,
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateComponentName validates that the name of a referenced OCM component has the same format as module names,
+// e.g. 'kyma-project.io/module/istio'.
+func ValidateComponentName(name string) error {
+	if name == "" {
+		return fmt.Errorf("component name must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if len(name) > moduleNameMaxLength || !componentRegexp.MatchString(name) {
+		return fmt.Errorf("component name '%s' must match the required pattern, e.g. 'kyma-project.io/module/istio' "+
+			"and must not exceed %d characters: %w", name, moduleNameMaxLength, commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateComponentVersion validates that the version of a referenced OCM component is a semantic version.
+func ValidateComponentVersion(version string) error {
+	if version == "" {
+		return fmt.Errorf("version must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if _, err := semver.StrictNewVersion(version); err != nil {
+		return fmt.Errorf("version '%s' must be a semantic version: %w", version, commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateResponsible validates a responsible of the cloud.gardener.cnudie/responsibles label. GitHub teams must have
+// the '<org>/<team>' format, GitHub users must be valid GitHub user names and email addresses must be plain addresses.
+// The GitHub hostname is optional and only allowed for GitHub teams and users.
+func ValidateResponsible(responsibleType, name, gitHubHostname string) error {
+	if name == "" {
+		return fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	switch responsibleType {
+	case common.ResponsibleTypeGitHubTeam:
+		if !gitHubTeamRegexp.MatchString(name) {
+			return fmt.Errorf("name '%s' must be a GitHub team in the '<org>/<team>' format: %w", name,
+				commonerrors.ErrInvalidOption)
+		}
+	case common.ResponsibleTypeGitHubUser:
+		if !gitHubUserRegexp.MatchString(name) {
+			return fmt.Errorf("name '%s' must be a GitHub user name: %w", name, commonerrors.ErrInvalidOption)
+		}
+	case common.ResponsibleTypeEmail:
+		if address, err := mail.ParseAddress(name); err != nil || address.Address != name {
+			return fmt.Errorf("name '%s' must be an email address: %w", name, commonerrors.ErrInvalidOption)
+		}
+		if gitHubHostname != "" {
+			return fmt.Errorf("GitHub hostname must not be set for type '%s': %w", responsibleType,
+				commonerrors.ErrInvalidOption)
+		}
+		return nil
+	default:
+		return fmt.Errorf("type '%s' must be one of %s, %s or %s: %w", responsibleType,
+			common.ResponsibleTypeGitHubTeam, common.ResponsibleTypeGitHubUser, common.ResponsibleTypeEmail,
+			commonerrors.ErrInvalidOption)
+	}
+
+	if gitHubHostname != "" && !hostnameRegexp.MatchString(gitHubHostname) {
+		return fmt.Errorf("GitHub hostname '%s' must be a lowercase hostname, e.g. 'github.com': %w", gitHubHostname,
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
 func validateSemanticVersion(version string) error {
 	_, err := semver.StrictNewVersion(strings.TrimSpace(version))
 	if err != nil {
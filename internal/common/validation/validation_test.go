@@ -1,6 +1,7 @@
 package validation_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/kyma-project/modulectl/internal/common/validation"
@@ -275,3 +276,235 @@ func TestValidateIsValidHttpsUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSHA256(t *testing.T) {
+	tests := []struct {
+		name     string
+		checksum string
+		wantErr  bool
+	}{
+		{
+			name:     "valid checksum",
+			checksum: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			wantErr:  false,
+		},
+		{
+			name:     "invalid checksum - uppercase",
+			checksum: "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid checksum - too short",
+			checksum: "e3b0c44298fc1c149afbf4c8996fb924",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid checksum - not hex",
+			checksum: "z3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid checksum - with algorithm prefix",
+			checksum: "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateSHA256(tt.checksum); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSHA256() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLabelName(t *testing.T) {
+	tests := []struct {
+		name      string
+		labelName string
+		wantErr   bool
+	}{
+		{name: "valid label name", labelName: "example.com/owner", wantErr: false},
+		{name: "valid label name with subdomain", labelName: "ocm.software.example.com/team_Name-1.x", wantErr: false},
+		{name: "invalid label name - empty", labelName: "", wantErr: true},
+		{name: "invalid label name - not qualified", labelName: "owner", wantErr: true},
+		{name: "invalid label name - not a DNS domain", labelName: "example/owner", wantErr: true},
+		{name: "invalid label name - uppercase domain", labelName: "Example.com/owner", wantErr: true},
+		{name: "invalid label name - multiple slashes", labelName: "example.com/team/owner", wantErr: true},
+		{name: "reserved label name - provider domain", labelName: "kyma-project.io/dirty", wantErr: true},
+		{name: "reserved label name - provider subdomain", labelName: "operator.kyma-project.io/beta", wantErr: true},
+		{name: "reserved label name - responsibles", labelName: "cloud.gardener.cnudie/responsibles", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateLabelName(tt.labelName); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLabelName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLabelVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr bool
+	}{
+		{name: "valid version - empty", version: "", wantErr: false},
+		{name: "valid version", version: "v1", wantErr: false},
+		{name: "valid version - multiple digits", version: "v12", wantErr: false},
+		{name: "invalid version - without prefix", version: "1", wantErr: true},
+		{name: "invalid version - semantic version", version: "v1.0.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateLabelVersion(tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLabelVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResourceName(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		wantErr      bool
+	}{
+		{name: "valid resource name", resourceName: "docs-bundle", wantErr: false},
+		{name: "valid resource name - digits", resourceName: "dashboard2", wantErr: false},
+		{name: "invalid resource name - empty", resourceName: "", wantErr: true},
+		{name: "invalid resource name - uppercase", resourceName: "Docs", wantErr: true},
+		{name: "invalid resource name - trailing hyphen", resourceName: "docs-", wantErr: true},
+		{name: "invalid resource name - slash", resourceName: "docs/user", wantErr: true},
+		{name: "reserved resource name - raw manifest", resourceName: "raw-manifest", wantErr: true},
+		{name: "reserved resource name - module template", resourceName: "moduletemplate", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateResourceName(tt.resourceName); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateResourceName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateComponentReferenceName(t *testing.T) {
+	tests := []struct {
+		name          string
+		referenceName string
+		wantErr       bool
+	}{
+		{name: "valid reference name", referenceName: "istio", wantErr: false},
+		{name: "valid reference name - hyphen", referenceName: "api-gateway", wantErr: false},
+		{name: "invalid reference name - empty", referenceName: "", wantErr: true},
+		{name: "invalid reference name - uppercase", referenceName: "Istio", wantErr: true},
+		{name: "invalid reference name - slash", referenceName: "kyma/istio", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateComponentReferenceName(tt.referenceName); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateComponentReferenceName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateComponentName(t *testing.T) {
+	tests := []struct {
+		name          string
+		componentName string
+		wantErr       bool
+	}{
+		{name: "valid component name", componentName: "kyma-project.io/module/istio", wantErr: false},
+		{name: "invalid component name - empty", componentName: "", wantErr: true},
+		{name: "invalid component name - without domain", componentName: "istio", wantErr: true},
+		{name: "invalid component name - uppercase", componentName: "kyma-project.io/module/Istio", wantErr: true},
+		{
+			name:          "invalid component name - too long",
+			componentName: "kyma-project.io/" + strings.Repeat("a", 250),
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateComponentName(tt.componentName); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateComponentName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateComponentVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr bool
+	}{
+		{name: "valid version", version: "1.2.0", wantErr: false},
+		{name: "valid version - pre-release", version: "1.2.0-rc.1", wantErr: false},
+		{name: "invalid version - empty", version: "", wantErr: true},
+		{name: "invalid version - v prefix", version: "v1.2.0", wantErr: true},
+		{name: "invalid version - incomplete", version: "1.2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateComponentVersion(tt.version); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateComponentVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResponsible(t *testing.T) {
+	tests := []struct {
+		name            string
+		responsibleType string
+		responsible     string
+		gitHubHostname  string
+		wantErr         bool
+	}{
+		{name: "valid GitHub team", responsibleType: "githubTeam", responsible: "kyma/jellyfish", wantErr: false},
+		{
+			name:            "valid GitHub team - hostname",
+			responsibleType: "githubTeam",
+			responsible:     "kyma-project/jellyfish",
+			gitHubHostname:  "github.com",
+			wantErr:         false,
+		},
+		{name: "valid GitHub user", responsibleType: "githubUser", responsible: "octo-cat", wantErr: false},
+		{name: "valid email", responsibleType: "emailAddress", responsible: "team@example.com", wantErr: false},
+		{name: "invalid - empty name", responsibleType: "githubTeam", responsible: "", wantErr: true},
+		{name: "invalid - unknown type", responsibleType: "email", responsible: "team@example.com", wantErr: true},
+		{name: "invalid GitHub team - without org", responsibleType: "githubTeam", responsible: "jellyfish", wantErr: true},
+		{name: "invalid GitHub user - slash", responsibleType: "githubUser", responsible: "kyma/octocat", wantErr: true},
+		{
+			name:            "invalid GitHub team - hostname with scheme",
+			responsibleType: "githubTeam",
+			responsible:     "kyma/jellyfish",
+			gitHubHostname:  "https://github.com",
+			wantErr:         true,
+		},
+		{
+			name:            "invalid email - display name",
+			responsibleType: "emailAddress",
+			responsible:     "Team <team@example.com>",
+			wantErr:         true,
+		},
+		{
+			name:            "invalid email - hostname",
+			responsibleType: "emailAddress",
+			responsible:     "team@example.com",
+			gitHubHostname:  "github.com",
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.ValidateResponsible(tt.responsibleType, tt.responsible, tt.gitHubHostname)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateResponsible() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
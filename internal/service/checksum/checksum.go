@@ -0,0 +1,102 @@
+package checksum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+type FileSystem interface {
+	FileExists(path string) (bool, error)
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path, content string) error
+}
+
+type Service struct {
+	fileSystem FileSystem
+}
+
+func NewService(fileSystem FileSystem) (*Service, error) {
+	if fileSystem == nil {
+		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		fileSystem: fileSystem,
+	}, nil
+}
+
+// LockFile records the SHA-256 checksums of remote files by URL.
+type LockFile struct {
+	Checksums map[string]string `yaml:"checksums"`
+}
+
+// SHA256 returns the hex encoded SHA-256 checksum of the file.
+func (s *Service) SHA256(filePath string) (string, error) {
+	data, err := s.fileSystem.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Verify computes the SHA-256 checksum of the file and compares it with the expected one. The computed checksum is
+// returned.
+func (s *Service) Verify(filePath, source, expected string) (string, error) {
+	actual, err := s.SHA256(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	if expected != "" && actual != expected {
+		return "", fmt.Errorf("%s: expected sha256 %s, got %s: %w", source, expected, actual, ErrChecksumMismatch)
+	}
+	return actual, nil
+}
+
+// LoadLockFile reads the lock file. If the lock file does not exist, an empty lock file is returned.
+func (s *Service) LoadLockFile(path string) (*LockFile, error) {
+	lockFile := &LockFile{Checksums: map[string]string{}}
+
+	exists, err := s.fileSystem.FileExists(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if lock file exists: %w", err)
+	}
+	if !exists {
+		return lockFile, nil
+	}
+
+	data, err := s.fileSystem.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, lockFile); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file %s: %w", path, err)
+	}
+	if lockFile.Checksums == nil {
+		lockFile.Checksums = map[string]string{}
+	}
+	return lockFile, nil
+}
+
+// SaveLockFile writes the lock file.
+func (s *Service) SaveLockFile(path string, lockFile *LockFile) error {
+	data, err := yaml.Marshal(lockFile)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lock file: %w", err)
+	}
+
+	if err := s.fileSystem.WriteFile(path, string(data)); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
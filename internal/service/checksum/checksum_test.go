@@ -0,0 +1,99 @@
+package checksum_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/checksum"
+)
+
+const (
+	helloWorld       = "hello world"
+	helloWorldSHA256 = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+)
+
+func TestNewService_ReturnsError_WhenFileSystemIsNil(t *testing.T) {
+	_, err := checksum.NewService(nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+}
+
+func TestService_Verify(t *testing.T) {
+	svc, _ := checksum.NewService(&fileSystemStub{files: map[string]string{"manifest.yaml": helloWorld}})
+
+	actual, err := svc.Verify("manifest.yaml", "https://example.com/manifest.yaml", helloWorldSHA256)
+	require.NoError(t, err)
+	require.Equal(t, helloWorldSHA256, actual)
+
+	actual, err = svc.Verify("manifest.yaml", "https://example.com/manifest.yaml", "")
+	require.NoError(t, err)
+	require.Equal(t, helloWorldSHA256, actual)
+}
+
+func TestService_Verify_ReturnsError_WhenChecksumDoesNotMatch(t *testing.T) {
+	svc, _ := checksum.NewService(&fileSystemStub{files: map[string]string{"manifest.yaml": "moved asset"}})
+
+	_, err := svc.Verify("manifest.yaml", "https://example.com/manifest.yaml", helloWorldSHA256)
+
+	require.ErrorIs(t, err, checksum.ErrChecksumMismatch)
+	require.ErrorContains(t, err, "https://example.com/manifest.yaml: expected sha256 "+helloWorldSHA256)
+}
+
+func TestService_LoadLockFile_ReturnsEmptyLockFile_WhenFileDoesNotExist(t *testing.T) {
+	svc, _ := checksum.NewService(&fileSystemStub{files: map[string]string{}})
+
+	lockFile, err := svc.LoadLockFile("module-config.lock")
+
+	require.NoError(t, err)
+	require.Empty(t, lockFile.Checksums)
+	require.NotNil(t, lockFile.Checksums)
+}
+
+func TestService_SaveAndLoadLockFile(t *testing.T) {
+	fs := &fileSystemStub{files: map[string]string{}}
+	svc, _ := checksum.NewService(fs)
+
+	err := svc.SaveLockFile("module-config.lock", &checksum.LockFile{
+		Checksums: map[string]string{"https://example.com/manifest.yaml": helloWorldSHA256},
+	})
+	require.NoError(t, err)
+
+	lockFile, err := svc.LoadLockFile("module-config.lock")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"https://example.com/manifest.yaml": helloWorldSHA256}, lockFile.Checksums)
+}
+
+func TestService_LoadLockFile_ReturnsError_WhenFileIsInvalid(t *testing.T) {
+	svc, _ := checksum.NewService(&fileSystemStub{files: map[string]string{"module-config.lock": "checksums: ["}})
+
+	_, err := svc.LoadLockFile("module-config.lock")
+
+	require.ErrorContains(t, err, "failed to parse lock file")
+}
+
+var errFileNotFound = errors.New("file not found")
+
+type fileSystemStub struct {
+	files map[string]string
+}
+
+func (s *fileSystemStub) FileExists(path string) (bool, error) {
+	_, ok := s.files[path]
+	return ok, nil
+}
+
+func (s *fileSystemStub) ReadFile(path string) ([]byte, error) {
+	content, ok := s.files[path]
+	if !ok {
+		return nil, errFileNotFound
+	}
+	return []byte(content), nil
+}
+
+func (s *fileSystemStub) WriteFile(path, content string) error {
+	s.files[path] = content
+	return nil
+}
@@ -2,16 +2,26 @@ package componentconstructor
 
 import (
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/kyma-project/modulectl/internal/common"
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/service/image"
 	"github.com/kyma-project/modulectl/tools/filesystem"
 )
 
+const (
+	bundleDirPerm  = 0o755
+	bundleFilePerm = 0o644
+)
+
 type Service struct{}
 
 func NewService() *Service {
@@ -36,6 +46,12 @@ func (s *Service) AddResources(
 	if err != nil {
 		return fmt.Errorf("failed to create moduletemplate resource: %w", err)
 	}
+	for _, resource := range resourcePaths.ComponentResources {
+		err = componentConstructor.AddCustomResource(resource.Name, resource.Type, resource.MediaType, resource.Path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s resource: %w", resource.Name, err)
+		}
+	}
 	return nil
 }
 
@@ -52,6 +68,69 @@ func (s *Service) CreateConstructorFile(componentConstructor *component.Construc
 	return nil
 }
 
+// RelativizeInputPaths makes the paths of all file inputs relative to the directory of the constructor file, so the
+// constructor file can be moved together with its inputs.
+func (s *Service) RelativizeInputPaths(componentConstructor *component.Constructor, constructorFile string) error {
+	constructorDir, err := filepath.Abs(filepath.Dir(constructorFile))
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of constructor file directory: %w", err)
+	}
+	if err = componentConstructor.RelativizeInputPaths(constructorDir); err != nil {
+		return fmt.Errorf("failed to relativize input paths: %w", err)
+	}
+	return nil
+}
+
+// BundleFileInputs copies the files of all file inputs to a subdirectory of bundleDir named after the resource and
+// points the inputs to the copies. Directory inputs without included files are copied entirely.
+func (s *Service) BundleFileInputs(componentConstructor *component.Constructor, bundleDir string) error {
+	absBundleDir, err := filepath.Abs(bundleDir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of bundle directory: %w", err)
+	}
+
+	for _, resource := range componentConstructor.Components[0].Resources {
+		input := resource.Input
+		if input == nil || input.Path == "" {
+			continue
+		}
+
+		resourceDir := filepath.Join(absBundleDir, resource.Name)
+		if err = os.RemoveAll(resourceDir); err != nil {
+			return fmt.Errorf("failed to clean bundle directory %s: %w", resourceDir, err)
+		}
+		if err = os.MkdirAll(resourceDir, bundleDirPerm); err != nil {
+			return fmt.Errorf("failed to create bundle directory %s: %w", resourceDir, err)
+		}
+
+		switch {
+		case input.Type == component.DirectoryInputType && len(input.IncludeFiles) == 0:
+			if err = copyDir(input.Path, resourceDir, absBundleDir); err != nil {
+				return fmt.Errorf("failed to bundle %s resource: %w", resource.Name, err)
+			}
+			input.Path = resourceDir
+		case input.Type == component.DirectoryInputType:
+			for _, file := range input.IncludeFiles {
+				target := filepath.Join(resourceDir, file)
+				if err = os.MkdirAll(filepath.Dir(target), bundleDirPerm); err != nil {
+					return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(target), err)
+				}
+				if err = filesystem.CopyFile(filepath.Join(input.Path, file), target, bundleFilePerm); err != nil {
+					return fmt.Errorf("failed to bundle %s resource: %w", resource.Name, err)
+				}
+			}
+			input.Path = resourceDir
+		default:
+			target := filepath.Join(resourceDir, filepath.Base(input.Path))
+			if err = filesystem.CopyFile(input.Path, target, bundleFilePerm); err != nil {
+				return fmt.Errorf("failed to bundle %s resource: %w", resource.Name, err)
+			}
+			input.Path = target
+		}
+	}
+	return nil
+}
+
 func (s *Service) AddImagesToConstructor(
 	componentConstructor *component.Constructor,
 	images []string,
@@ -90,15 +169,128 @@ func (s *Service) SetComponentLabel(
 func (s *Service) SetResponsiblesLabel(
 	componentConstructor *component.Constructor,
 	team string,
+) {
+	s.SetResponsibles(componentConstructor, []component.ResponsibleEntry{{
+		GitHubHostname: common.GitHubHostname,
+		TeamName:       team,
+		Type:           common.ResponsibleTypeGitHubTeam,
+	}})
+}
+
+// SetResponsibles sets the cloud.gardener.cnudie/responsibles label with the given responsibles.
+func (s *Service) SetResponsibles(
+	componentConstructor *component.Constructor,
+	responsibles []component.ResponsibleEntry,
 ) {
 	label := component.Label{
-		Name: common.ResponsiblesLabelKey,
-		Value: []component.ResponsibleEntry{{
-			GitHubHostname: common.GitHubHostname,
-			TeamName:       team,
-			Type:           common.ResponsibleTypeGitHubTeam,
-		}},
+		Name:    common.ResponsiblesLabelKey,
+		Value:   responsibles,
 		Version: common.VersionV1,
 	}
 	componentConstructor.Components[0].Labels = append(componentConstructor.Components[0].Labels, label)
 }
+
+// AddLabels adds user-defined labels to the first component of the constructor and to its resources and sources,
+// which are referenced by name. The label version defaults to "v1". Labels that are already set are not overwritten.
+func (s *Service) AddLabels(
+	componentConstructor *component.Constructor,
+	componentLabels []component.Label,
+	resourceLabels, sourceLabels map[string][]component.Label,
+) error {
+	comp := &componentConstructor.Components[0]
+
+	labels, err := appendLabels(comp.Labels, componentLabels)
+	if err != nil {
+		return fmt.Errorf("failed to add component labels: %w", err)
+	}
+	comp.Labels = labels
+
+	for _, name := range slices.Sorted(maps.Keys(resourceLabels)) {
+		index := slices.IndexFunc(comp.Resources, func(resource component.Resource) bool {
+			return resource.Name == name
+		})
+		if index < 0 {
+			return fmt.Errorf("failed to add labels to resource: %w: %s", commonerrors.ErrUnknownResourceName, name)
+		}
+		if labels, err = appendLabels(comp.Resources[index].Labels, resourceLabels[name]); err != nil {
+			return fmt.Errorf("failed to add labels to resource %s: %w", name, err)
+		}
+		comp.Resources[index].Labels = labels
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(sourceLabels)) {
+		index := slices.IndexFunc(comp.Sources, func(source component.Source) bool {
+			return source.Name == name
+		})
+		if index < 0 {
+			return fmt.Errorf("failed to add labels to source: unknown source name: %s: %w", name,
+				commonerrors.ErrInvalidOption)
+		}
+		if labels, err = appendLabels(comp.Sources[index].Labels, sourceLabels[name]); err != nil {
+			return fmt.Errorf("failed to add labels to source %s: %w", name, err)
+		}
+		comp.Sources[index].Labels = labels
+	}
+	return nil
+}
+
+// AddComponentReferences adds references to the component versions the first component of the constructor is composed
+// of.
+func (s *Service) AddComponentReferences(
+	componentConstructor *component.Constructor,
+	references []component.ComponentReference,
+) error {
+	for _, reference := range references {
+		err := componentConstructor.AddComponentReference(reference.Name, reference.ComponentName, reference.Version)
+		if err != nil {
+			return fmt.Errorf("failed to add component reference %s: %w", reference.Name, err)
+		}
+	}
+	return nil
+}
+
+func appendLabels(labels, additionalLabels []component.Label) ([]component.Label, error) {
+	for _, label := range additionalLabels {
+		if slices.ContainsFunc(labels, func(existing component.Label) bool { return existing.Name == label.Name }) {
+			return nil, fmt.Errorf("label %s is already set: %w", label.Name, commonerrors.ErrInvalidOption)
+		}
+		if label.Version == "" {
+			label.Version = common.VersionV1
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// copyDir copies the source directory to the target directory. The skipped directory, which must be an absolute
+// path, is not copied, so that a bundle directory inside the source directory is not copied into itself.
+func copyDir(source, target, skippedDir string) error {
+	err := filepath.WalkDir(source, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path of %s: %w", path, err)
+		}
+		if entry.IsDir() && absPath == skippedDir {
+			return filepath.SkipDir
+		}
+		relPath, err := filepath.Rel(source, path)
+		if err != nil {
+			return fmt.Errorf("failed to get path of %s relative to %s: %w", path, source, err)
+		}
+		targetPath := filepath.Join(target, relPath)
+		if entry.IsDir() {
+			if err = os.MkdirAll(targetPath, bundleDirPerm); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", targetPath, err)
+			}
+			return nil
+		}
+		return filesystem.CopyFile(path, targetPath, bundleFilePerm)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy directory %s: %w", source, err)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/kyma-project/modulectl/internal/common"
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/service/componentconstructor"
 )
@@ -213,3 +214,94 @@ func TestService_SetResponsiblesLabel_AndSecurityScanLabel(t *testing.T) {
 	assert.Equal(t, common.SecurityScanLabelKey, securityLabel.Name)
 	assert.Equal(t, common.SecurityScanEnabledValue, securityLabel.Value)
 }
+
+func TestService_AddLabels_AddsLabelsToComponentResourcesAndSources(t *testing.T) {
+	service := componentconstructor.NewService()
+
+	// given
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	constructor.AddGitSource("https://github.com/kyma-project/template-operator", "abcdef")
+	require.NoError(t, constructor.AddFileResource(common.RawManifestResourceName, "manifest.yaml"))
+	service.SetComponentLabel(constructor, common.SecurityScanLabelKey, common.SecurityScanEnabledValue)
+
+	// when
+	err := service.AddLabels(constructor,
+		[]component.Label{{Name: "example.com/owner", Value: map[string]any{"team": "jellyfish"}, Version: "v2"}},
+		map[string][]component.Label{common.RawManifestResourceName: {{Name: "example.com/purpose", Value: "deploy"}}},
+		map[string][]component.Label{common.OCMIdentityName: {{Name: "example.com/reviewed", Value: true}}})
+
+	// then
+	require.NoError(t, err)
+	labels := constructor.Components[0].Labels
+	require.Len(t, labels, 2)
+	assert.Equal(t, component.Label{
+		Name: "example.com/owner", Value: map[string]any{"team": "jellyfish"}, Version: "v2",
+	}, labels[1])
+	assert.Equal(t, []component.Label{{Name: "example.com/purpose", Value: "deploy", Version: common.VersionV1}},
+		constructor.Components[0].Resources[0].Labels)
+	assert.Equal(t, []component.Label{{Name: "example.com/reviewed", Value: true, Version: common.VersionV1}},
+		constructor.Components[0].Sources[0].Labels)
+}
+
+func TestService_AddLabels_ReturnsError_WhenResourceDoesNotExist(t *testing.T) {
+	service := componentconstructor.NewService()
+
+	// given
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+
+	// when
+	err := service.AddLabels(constructor, nil,
+		map[string][]component.Label{"unknown": {{Name: "example.com/purpose", Value: "deploy"}}}, nil)
+
+	// then
+	require.ErrorIs(t, err, commonerrors.ErrUnknownResourceName)
+	require.ErrorContains(t, err, "unknown")
+}
+
+func TestService_AddLabels_ReturnsError_WhenSourceDoesNotExist(t *testing.T) {
+	service := componentconstructor.NewService()
+
+	// given
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+
+	// when
+	err := service.AddLabels(constructor, nil, nil,
+		map[string][]component.Label{common.OCMIdentityName: {{Name: "example.com/reviewed", Value: true}}})
+
+	// then
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.ErrorContains(t, err, "unknown source name: module-sources")
+}
+
+func TestService_AddLabels_ReturnsError_WhenLabelIsAlreadySet(t *testing.T) {
+	service := componentconstructor.NewService()
+
+	// given
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	service.SetComponentLabel(constructor, "example.com/owner", "jellyfish")
+
+	// when
+	err := service.AddLabels(constructor,
+		[]component.Label{{Name: "example.com/owner", Value: "jellyfish"}}, nil, nil)
+
+	// then
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.ErrorContains(t, err, "label example.com/owner is already set")
+}
+
+func TestService_SetResponsibles(t *testing.T) {
+	service := componentconstructor.NewService()
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	responsibles := []component.ResponsibleEntry{
+		{GitHubHostname: "github.com", Username: "octocat", Type: common.ResponsibleTypeGitHubUser},
+		{Email: "jellyfish@example.com", Type: common.ResponsibleTypeEmail},
+	}
+
+	service.SetResponsibles(constructor, responsibles)
+
+	require.Len(t, constructor.Components[0].Labels, 1)
+	label := constructor.Components[0].Labels[0]
+	assert.Equal(t, common.ResponsiblesLabelKey, label.Name)
+	assert.Equal(t, common.VersionV1, label.Version)
+	assert.Equal(t, responsibles, label.Value)
+}
@@ -1,12 +1,14 @@
 package componentconstructor_test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/kyma-project/modulectl/internal/common"
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/service/componentconstructor"
@@ -144,6 +146,120 @@ func TestService_AddResourcesAndCreateConstructorFile_WithDefaultCR(t *testing.T
 	require.Contains(t, resourceNames, common.ModuleTemplateResourceName)
 }
 
+func TestService_BundleFileInputs_CopiesFilesAndRelativizesPaths(t *testing.T) {
+	service := componentconstructor.NewService()
+	sourceDir := t.TempDir()
+	manifestPath := filepath.Join(sourceDir, "manifest.yaml")
+	templatePath := filepath.Join(sourceDir, "template.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("manifest"), 0o600))
+	require.NoError(t, os.WriteFile(templatePath, []byte("template"), 0o600))
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	require.NoError(t, service.AddResources(constructor, types.NewResourcePaths("", manifestPath, templatePath)))
+	outputDir := t.TempDir()
+	outputFile := filepath.Join(outputDir, testOutputFileName)
+
+	err := service.BundleFileInputs(constructor, filepath.Join(outputDir, "output-bundle"))
+	require.NoError(t, err)
+	err = service.RelativizeInputPaths(constructor, outputFile)
+	require.NoError(t, err)
+
+	resources := constructor.Components[0].Resources
+	require.Equal(t, "output-bundle/"+common.RawManifestResourceName, resources[0].Input.Path)
+	require.Equal(t, []string{"manifest.yaml"}, resources[0].Input.IncludeFiles)
+	require.Equal(t, "output-bundle/"+common.ModuleTemplateResourceName+"/template.yaml", resources[1].Input.Path)
+	content, err := os.ReadFile(filepath.Join(outputDir, "output-bundle", common.RawManifestResourceName,
+		"manifest.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "manifest", string(content))
+	require.FileExists(t, filepath.Join(outputDir, resources[1].Input.Path))
+}
+
+func TestService_AddResources_WithComponentResources(t *testing.T) {
+	service := componentconstructor.NewService()
+	docsDir := t.TempDir()
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	resourcePaths := types.NewResourcePaths("", testManifestPath, testModuleTemplatePath)
+	resourcePaths.ComponentResources = []types.ComponentResource{
+		{Name: "docs", Type: "directoryTree", MediaType: "application/x-tar", Path: docsDir},
+	}
+
+	err := service.AddResources(constructor, resourcePaths)
+
+	require.NoError(t, err)
+	resources := constructor.Components[0].Resources
+	require.Len(t, resources, 3)
+	require.Equal(t, "docs", resources[2].Name)
+	require.Equal(t, "directoryTree", resources[2].Type)
+	require.Equal(t, &component.Input{Type: component.DirectoryInputType, Path: docsDir, MediaType: "application/x-tar"},
+		resources[2].Input)
+}
+
+func TestService_BundleFileInputs_CopiesDirectoryInputs(t *testing.T) {
+	service := componentconstructor.NewService()
+	docsDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(docsDir, "user"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(docsDir, "user", "README.md"), []byte("docs"), 0o600))
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	require.NoError(t, constructor.AddCustomResource("docs", "directoryTree", "", docsDir))
+	bundleDir := t.TempDir()
+
+	err := service.BundleFileInputs(constructor, bundleDir)
+
+	require.NoError(t, err)
+	input := constructor.Components[0].Resources[0].Input
+	require.Equal(t, filepath.Join(bundleDir, "docs"), input.Path)
+	content, err := os.ReadFile(filepath.Join(bundleDir, "docs", "user", "README.md"))
+	require.NoError(t, err)
+	require.Equal(t, "docs", string(content))
+}
+
+func TestService_BundleFileInputs_SkipsBundleDir_WhenInsideDirectoryInput(t *testing.T) {
+	service := componentconstructor.NewService()
+	moduleDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "README.md"), []byte("docs"), 0o600))
+	bundleDir := filepath.Join(moduleDir, "component-constructor-bundle")
+	require.NoError(t, os.MkdirAll(filepath.Join(bundleDir, "raw-manifest"), 0o755))
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	require.NoError(t, constructor.AddCustomResource("docs", "directoryTree", "", moduleDir))
+
+	err := service.BundleFileInputs(constructor, bundleDir)
+
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(bundleDir, "docs", "README.md"))
+	require.NoDirExists(t, filepath.Join(bundleDir, "docs", "component-constructor-bundle"))
+}
+
+func TestService_BundleFileInputs_CopiesNestedIncludedFiles(t *testing.T) {
+	service := componentconstructor.NewService()
+	docsDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(docsDir, "user", "guides"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(docsDir, "user", "guides", "setup.md"), []byte("setup"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(docsDir, "excluded.md"), []byte("excluded"), 0o600))
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	require.NoError(t, constructor.AddCustomResource("docs", "directoryTree", "", docsDir))
+	constructor.Components[0].Resources[0].Input.IncludeFiles = []string{"user/guides/setup.md"}
+	bundleDir := t.TempDir()
+
+	err := service.BundleFileInputs(constructor, bundleDir)
+
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(bundleDir, "docs", "user", "guides", "setup.md"))
+	require.NoError(t, err)
+	require.Equal(t, "setup", string(content))
+	require.NoFileExists(t, filepath.Join(bundleDir, "docs", "excluded.md"))
+}
+
+func TestService_BundleFileInputs_ReturnsError_WhenInputDoesNotExist(t *testing.T) {
+	service := componentconstructor.NewService()
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	require.NoError(t, service.AddResources(constructor,
+		types.NewResourcePaths("", testManifestPath, testModuleTemplatePath)))
+
+	err := service.BundleFileInputs(constructor, t.TempDir())
+
+	require.ErrorContains(t, err, "failed to bundle raw-manifest resource")
+}
+
 func TestService_AddImagesToConstructor_Success(t *testing.T) {
 	service := componentconstructor.NewService()
 	constructor := component.NewConstructor(testModuleName, testModuleVersion)
@@ -305,3 +421,31 @@ func TestService_AddImagesToConstructor_SingleImage(t *testing.T) {
 	require.NotEmpty(t, imageResource.Name)
 	require.NotEmpty(t, imageResource.Version)
 }
+
+func TestService_AddComponentReferences(t *testing.T) {
+	service := componentconstructor.NewService()
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	references := []component.ComponentReference{
+		{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+		{Name: "api-gateway", ComponentName: "kyma-project.io/module/api-gateway", Version: "2.0.0"},
+	}
+
+	err := service.AddComponentReferences(constructor, references)
+
+	require.NoError(t, err)
+	require.Equal(t, references, constructor.Components[0].ComponentReferences)
+}
+
+func TestService_AddComponentReferences_ReturnsError_WhenReferenceIsDuplicated(t *testing.T) {
+	service := componentconstructor.NewService()
+	constructor := component.NewConstructor(testModuleName, testModuleVersion)
+	references := []component.ComponentReference{
+		{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+		{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.3.0"},
+	}
+
+	err := service.AddComponentReferences(constructor, references)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.ErrorContains(t, err, "failed to add component reference istio")
+}
@@ -2,13 +2,18 @@ package componentdescriptor
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/kyma-project/modulectl/internal/common"
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
 )
 
 type GitService interface {
 	GetLatestCommit(gitRepoPath string) (string, error)
+	GetUncommittedChanges(gitRepoPath string) ([]string, error)
+	GetMetadata(gitRepoPath string) (*types.GitMetadata, error)
 }
 
 type GitSourcesService struct {
@@ -33,6 +38,54 @@ func (s *GitSourcesService) AddGitSourcesToConstructor(constructor *component.Co
 		return fmt.Errorf("failed to get latest commit: %w", err)
 	}
 
+	changes, err := s.gitService.GetUncommittedChanges(gitRepoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get uncommitted changes: %w", err)
+	}
+
+	metadata, err := s.gitService.GetMetadata(gitRepoPath)
+	if err != nil {
+		return fmt.Errorf("failed to get git metadata: %w", err)
+	}
+
 	constructor.AddGitSource(gitRepoURL, latestCommit)
+	addMetadataLabels(constructor, metadata)
+	// The commit does not match the packaged sources if the working tree has uncommitted changes.
+	if len(changes) > 0 {
+		constructor.AddSourceLabel(common.OCMIdentityName, common.DirtyLabelKey, common.DirtyLabelValue,
+			common.VersionV1)
+	}
 	return nil
 }
+
+// addMetadataLabels labels the git source with the subdirectory of the module sources and the branch, tags, commit
+// timestamp and signature types of the commit, so the component version can be traced back to the release tag it was
+// built from.
+func addMetadataLabels(constructor *component.Constructor, metadata *types.GitMetadata) {
+	addLabel := func(key string, value any) {
+		constructor.AddSourceLabel(common.OCMIdentityName, key, value, common.VersionV1)
+	}
+
+	if metadata.Subdirectory != "" {
+		addLabel(common.GitSubdirectoryLabelKey, metadata.Subdirectory)
+	}
+	if metadata.Branch != "" {
+		addLabel(common.GitBranchLabelKey, metadata.Branch)
+	}
+	if len(metadata.Tags) > 0 {
+		addLabel(common.GitTagsLabelKey, metadata.Tags)
+	}
+	addLabel(common.GitCommitTimestampLabelKey, metadata.CommitTime.UTC().Format(time.RFC3339))
+	if metadata.CommitSignatureType != "" || len(metadata.TagSignatureTypes) > 0 {
+		addLabel(common.GitSignatureTypeLabelKey, component.GitSignatureTypes{
+			Commit: metadata.CommitSignatureType,
+			Tags:   metadata.TagSignatureTypes,
+		})
+	}
+}
+
+// AddGitSourceWithCommit adds the git source with the given commit to the constructor without opening a repository,
+// for example, if the module sources are an exported source archive.
+func (s *GitSourcesService) AddGitSourceWithCommit(constructor *component.Constructor, gitRepoURL, commit string) {
+	constructor.AddGitSource(gitRepoURL, commit)
+}
@@ -3,9 +3,12 @@ package componentdescriptor_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/kyma-project/modulectl/internal/common"
+	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/service/componentdescriptor"
 )
@@ -25,13 +28,72 @@ func TestGitSourcesService_AddGitSourcesToConstructor_AddsCorrectSource(t *testi
 	require.Equal(t, component.GithubSourceType, source.Type)
 	require.Equal(t, "module-sources", source.Name)
 	require.Equal(t, "1.0.0", source.Version)
-	require.Empty(t, source.Labels)
+	require.Equal(t, []component.Label{{
+		Name:    common.GitCommitTimestampLabelKey,
+		Value:   "2026-01-02T03:04:05Z",
+		Version: common.VersionV1,
+	}}, source.Labels)
 	require.NotNil(t, source.Access)
 	require.Equal(t, component.GithubAccessType, source.Access.Type)
 	require.Equal(t, "gitRepoUrl", source.Access.RepoUrl)
 	require.Equal(t, "abcdefg", source.Access.Commit)
 }
 
+func TestGitSourcesService_AddGitSourcesToConstructor_AddsDirtyLabel_WhenWorkingTreeHasChanges(t *testing.T) {
+	gitSourcesService, err := componentdescriptor.NewGitSourcesService(&gitServiceStub{
+		latestCommit: "abcdefg",
+		changes:      []string{"config/manifest.yaml"},
+	})
+	require.NoError(t, err)
+
+	constructor := component.NewConstructor("test.io/module/test", "1.0.0")
+
+	err = gitSourcesService.AddGitSourcesToConstructor(constructor, "gitRepoPath", "gitRepoUrl")
+
+	require.NoError(t, err)
+	require.Len(t, constructor.Components[0].Sources, 1)
+	require.Contains(t, constructor.Components[0].Sources[0].Labels, component.Label{
+		Name:    common.DirtyLabelKey,
+		Value:   common.DirtyLabelValue,
+		Version: common.VersionV1,
+	})
+}
+
+func TestGitSourcesService_AddGitSourcesToConstructor_AddsMetadataLabels(t *testing.T) {
+	gitSourcesService, err := componentdescriptor.NewGitSourcesService(&gitServiceStub{
+		latestCommit: "abcdefg",
+		metadata: &types.GitMetadata{
+			Subdirectory:        "modules/telemetry",
+			Branch:              "main",
+			Tags:                []string{"1.0.0"},
+			CommitTime:          time.Date(2026, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600)),
+			CommitSignatureType: "ssh",
+			TagSignatureTypes:   map[string]string{"1.0.0": "gpg"},
+		},
+	})
+	require.NoError(t, err)
+
+	constructor := component.NewConstructor("test.io/module/test", "1.0.0")
+
+	err = gitSourcesService.AddGitSourcesToConstructor(constructor, "gitRepoPath", "gitRepoUrl")
+
+	require.NoError(t, err)
+	require.Equal(t, []component.Label{
+		{Name: common.GitSubdirectoryLabelKey, Value: "modules/telemetry", Version: common.VersionV1},
+		{Name: common.GitBranchLabelKey, Value: "main", Version: common.VersionV1},
+		{Name: common.GitTagsLabelKey, Value: []string{"1.0.0"}, Version: common.VersionV1},
+		{Name: common.GitCommitTimestampLabelKey, Value: "2026-01-02T03:04:05Z", Version: common.VersionV1},
+		{
+			Name: common.GitSignatureTypeLabelKey,
+			Value: component.GitSignatureTypes{
+				Commit: "ssh",
+				Tags:   map[string]string{"1.0.0": "gpg"},
+			},
+			Version: common.VersionV1,
+		},
+	}, constructor.Components[0].Sources[0].Labels)
+}
+
 func TestGitSourcesService_AddGitSourcesToConstructor_ReturnsErrorOnCommitRetrievalError(t *testing.T) {
 	gitSourcesService, err := componentdescriptor.NewGitSourcesService(&gitServiceErrorStub{})
 	require.NoError(t, err)
@@ -45,16 +107,53 @@ func TestGitSourcesService_AddGitSourcesToConstructor_ReturnsErrorOnCommitRetrie
 	require.Empty(t, constructor.Components[0].Sources)
 }
 
+func TestGitSourcesService_AddGitSourceWithCommit_AddsSourceWithoutLabels(t *testing.T) {
+	gitSourcesService, err := componentdescriptor.NewGitSourcesService(&gitServiceErrorStub{})
+	require.NoError(t, err)
+
+	constructor := component.NewConstructor("test.io/module/test", "1.0.0")
+
+	gitSourcesService.AddGitSourceWithCommit(constructor, "gitRepoUrl", "abcdefg")
+
+	require.Len(t, constructor.Components[0].Sources, 1)
+	source := constructor.Components[0].Sources[0]
+	require.Equal(t, "module-sources", source.Name)
+	require.Empty(t, source.Labels)
+	require.Equal(t, "gitRepoUrl", source.Access.RepoUrl)
+	require.Equal(t, "abcdefg", source.Access.Commit)
+}
+
 type gitServiceStub struct {
 	latestCommit string
+	changes      []string
+	metadata     *types.GitMetadata
 }
 
 func (gs *gitServiceStub) GetLatestCommit(_ string) (string, error) {
 	return gs.latestCommit, nil
 }
 
+func (gs *gitServiceStub) GetUncommittedChanges(_ string) ([]string, error) {
+	return gs.changes, nil
+}
+
+func (gs *gitServiceStub) GetMetadata(_ string) (*types.GitMetadata, error) {
+	if gs.metadata != nil {
+		return gs.metadata, nil
+	}
+	return &types.GitMetadata{CommitTime: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}, nil
+}
+
 type gitServiceErrorStub struct{}
 
 func (*gitServiceErrorStub) GetLatestCommit(_ string) (string, error) {
 	return "", errors.New("failed to get commit")
 }
+
+func (*gitServiceErrorStub) GetUncommittedChanges(_ string) ([]string, error) {
+	return nil, errors.New("failed to get status")
+}
+
+func (*gitServiceErrorStub) GetMetadata(_ string) (*types.GitMetadata, error) {
+	return nil, errors.New("failed to get metadata")
+}
@@ -8,6 +8,7 @@ import (
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/common/types/component"
 )
 
 var ErrDuplicateMapEntries = errors.New("map contains duplicate entries")
@@ -81,31 +82,64 @@ func (s *ModuleConfigProvider) validateArgs(args types.KeyValueArgs) error {
 }
 
 type ModuleConfig struct {
-	Name                string                     `comment:"required, the name of the module"                                                                                                  yaml:"name"`
-	Version             string                     `comment:"required, the version of the module"                                                                                               yaml:"version"`
-	Manifest            UrlOrLocalFile             `comment:"required, reference to the manifest, must be a URL or a local file path"                                                           yaml:"manifest"`
-	Repository          string                     `comment:"required, reference to the repository, must be a URL"                                                                              yaml:"repository"`
-	Team                string                     `comment:"required when securityScanEnabled is true (default), module team in the 'kyma/<your-team-name>' format (e.g., 'kyma/jellyfish')"   yaml:"team"`
-	Documentation       string                     `comment:"required, reference to the documentation, must be a URL"                                                                           yaml:"documentation"`
-	Icons               Icons                      `comment:"required, icons used for UI"                                                                                                       yaml:"icons,omitempty"`
-	DefaultCR           UrlOrLocalFile             `comment:"optional, reference to a YAML file containing the default CR for the module, must be a URL or a local file path"                   yaml:"defaultCR"` //nolint:tagliatelle // prefer defaultCR over defaultCr
-	Security            string                     `comment:"optional, reference to a YAML file containing the security scanners config, must be a local file path"                             yaml:"security"`
-	SecurityScanEnabled *bool                      `comment:"optional, default=true, indicates whether security scanning labels should be added to the OCM descriptor"                          yaml:"securityScanEnabled"`
-	Labels              map[string]string          `comment:"optional, additional labels for the generated ModuleTemplate CR"                                                                   yaml:"labels"`
-	Annotations         map[string]string          `comment:"optional, additional annotations for the generated ModuleTemplate CR"                                                              yaml:"annotations"`
-	Manager             *Manager                   `comment:"optional, module resource that indicates the installation readiness of the module, typically the manager deployment of the module" yaml:"manager"`
-	AssociatedResources []*metav1.GroupVersionKind `comment:"optional, optional, resources that should be cleaned up with the module deletion"                                                  yaml:"associatedResources"`
-	Resources           Resources                  `comment:"optional, additional resources of the module that may be fetched"                                                                  yaml:"resources,omitempty"`
-	RequiresDowntime    bool                       `comment:"optional, default=false, indicates whether the module requires downtime to support maintenance windows during module upgrades"     yaml:"requiresDowntime"`
-	Internal            bool                       `comment:"optional, default=false, indicates whether the module is internal"                                                                 yaml:"internal"`
-	Beta                bool                       `comment:"optional, default=false, indicates whether the module is beta"                                                                     yaml:"beta"`
+	Name                string                       `comment:"required, the name of the module"                                                                                                  yaml:"name"`
+	Version             string                       `comment:"required, the version of the module"                                                                                               yaml:"version"`
+	Manifest            UrlOrLocalFile               `comment:"required, reference to the manifest, must be a URL or a local file path"                                                           yaml:"manifest"`
+	ManifestSHA256      string                       `comment:"optional, SHA-256 checksum the manifest must match"                                                                                yaml:"manifestSHA256,omitempty"` //nolint:tagliatelle // prefer manifestSHA256 over manifestSha256
+	Repository          string                       `comment:"optional, reference to the repository, must be a URL, defaults to the origin git remote"                                           yaml:"repository"`
+	Team                string                       `comment:"required when securityScanEnabled is true (default) and no responsibles are set, module team in the 'kyma/<team>' format"          yaml:"team"`
+	Responsibles        []Responsible                `comment:"optional, responsibles of the module set in the responsibles OCM label instead of the team"                                        yaml:"responsibles,omitempty"`
+	Documentation       string                       `comment:"required, reference to the documentation, must be a URL"                                                                           yaml:"documentation"`
+	Icons               Icons                        `comment:"required, icons used for UI"                                                                                                       yaml:"icons,omitempty"`
+	DefaultCR           UrlOrLocalFile               `comment:"optional, reference to a YAML file containing the default CR for the module, must be a URL or a local file path"                   yaml:"defaultCR"`                 //nolint:tagliatelle // prefer defaultCR over defaultCr
+	DefaultCRSHA256     string                       `comment:"optional, SHA-256 checksum the default CR must match"                                                                              yaml:"defaultCRSHA256,omitempty"` //nolint:tagliatelle // prefer defaultCRSHA256 over defaultCrSha256
+	PrimaryCRD          *metav1.GroupKind            `comment:"optional, group and kind of the module's primary CRD, used to determine whether the module is cluster-scoped"                      yaml:"primaryCRD"`                //nolint:tagliatelle // prefer primaryCRD over primaryCrd
+	Security            string                       `comment:"optional, reference to a YAML file containing the security scanners config, must be a local file path"                             yaml:"security"`
+	SecurityScanEnabled *bool                        `comment:"optional, default=true, indicates whether security scanning labels should be added to the OCM descriptor"                          yaml:"securityScanEnabled"`
+	Labels              map[string]string            `comment:"optional, additional labels for the generated ModuleTemplate CR"                                                                   yaml:"labels"`
+	Annotations         map[string]string            `comment:"optional, additional annotations for the generated ModuleTemplate CR"                                                              yaml:"annotations"`
+	ComponentLabels     []component.Label            `comment:"optional, additional OCM labels of the component"                                                                                  yaml:"componentLabels,omitempty"`
+	ResourceLabels      map[string][]component.Label `comment:"optional, additional OCM labels of the component resources by resource name"                                                       yaml:"resourceLabels,omitempty"`
+	SourceLabels        map[string][]component.Label `comment:"optional, additional OCM labels of the component sources by source name"                                                           yaml:"sourceLabels,omitempty"`
+	ComponentResources  []ComponentResource          `comment:"optional, additional OCM resources of the component, e.g. documentation bundles or dashboards"                                     yaml:"componentResources,omitempty"`
+	ComponentReferences []ComponentReference         `comment:"optional, references to the OCM components the module is composed of"                                                              yaml:"componentReferences,omitempty"`
+	Manager             *Manager                     `comment:"optional, module resource that indicates the installation readiness of the module, typically the manager deployment of the module" yaml:"manager"`
+	AssociatedResources []*metav1.GroupVersionKind   `comment:"optional, optional, resources that should be cleaned up with the module deletion"                                                  yaml:"associatedResources"`
+	Resources           Resources                    `comment:"optional, additional resources of the module that may be fetched"                                                                  yaml:"resources,omitempty"`
+	RequiresDowntime    bool                         `comment:"optional, default=false, indicates whether the module requires downtime to support maintenance windows during module upgrades"     yaml:"requiresDowntime"`
+	Internal            bool                         `comment:"optional, default=false, indicates whether the module is internal"                                                                 yaml:"internal"`
+	Beta                bool                         `comment:"optional, default=false, indicates whether the module is beta"                                                                     yaml:"beta"`
+}
+
+// ComponentResource is an additional OCM resource of the module component whose content is read from a file or
+// directory.
+type ComponentResource struct {
+	Name      string         `comment:"required, the name of the resource"                                          yaml:"name"`
+	Type      string         `comment:"required, the OCM type of the resource, e.g. PlainText or directoryTree"     yaml:"type"`
+	MediaType string         `comment:"optional, the media type of the resource content"                            yaml:"mediaType,omitempty"`
+	Path      UrlOrLocalFile `comment:"required, reference to the file or directory, must be a URL or a local path" yaml:"path"`
+}
+
+// Responsible is a responsible of the module, either a GitHub team, a GitHub user or an email address.
+type Responsible struct {
+	Type           string `comment:"required, the type of the responsible, githubTeam, githubUser or emailAddress"                     yaml:"type"`
+	Name           string `comment:"required, the team name in the '<org>/<team>' format, the GitHub user name or the email address"   yaml:"name"`
+	GitHubHostname string `comment:"optional, default=github.tools.sap, the GitHub hostname of a githubTeam or githubUser responsible" yaml:"githubHostname,omitempty"`
+}
+
+// ComponentReference references a version of another OCM component the module component is composed of.
+type ComponentReference struct {
+	Name          string `comment:"required, the name of the reference, unique within the component"                  yaml:"name"`
+	ComponentName string `comment:"required, the name of the referenced component, e.g. kyma-project.io/module/istio" yaml:"componentName"`
+	Version       string `comment:"required, the semantic version of the referenced component"                        yaml:"version"`
 }
 
 type Manager struct {
 	metav1.GroupVersionKind `comment:"required, the GVK of the manager" yaml:",inline"`
 
-	Name      string `comment:"required, the name of the manager" yaml:"name"`
-	Namespace string `comment:"optional, the path to the manager" yaml:"namespace"`
+	Name          string `comment:"required, the name of the manager"                                                               yaml:"name"`
+	Namespace     string `comment:"optional, the path to the manager"                                                               yaml:"namespace"`
+	ContainerName string `comment:"optional, the name of the container whose image tag must match the module version, default=all" yaml:"containerName,omitempty"`
 }
 
 // Icons represents a map of icon names to links.
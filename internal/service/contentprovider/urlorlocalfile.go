@@ -7,6 +7,15 @@ import (
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 )
 
+const (
+	// SchemeHTTPS references a file that is downloaded over HTTPS.
+	SchemeHTTPS = "https"
+	// SchemeOCI references a single-layer OCI artifact in the format oci://registry/repository:tag.
+	SchemeOCI = "oci"
+	// SchemeGitHTTPS references a file in a git repository in the format git+https://host/repository//path@ref.
+	SchemeGitHTTPS = "git+https"
+)
+
 // UrlOrLocalFile is a struct that represents either a URL or a local file path.
 //
 //nolint:recvcheck // This is a value type, not a pointer type.
@@ -28,6 +37,19 @@ func (u UrlOrLocalFile) IsURL() bool {
 	return u.url != nil
 }
 
+// IsRemote returns true if the URL uses one of the schemes supported for remote files: https, oci or git+https.
+func (u UrlOrLocalFile) IsRemote() bool {
+	if u.url == nil {
+		return false
+	}
+	switch u.url.Scheme {
+	case SchemeHTTPS, SchemeOCI, SchemeGitHTTPS:
+		return true
+	default:
+		return false
+	}
+}
+
 func (u UrlOrLocalFile) URL() *url.URL {
 	return u.url
 }
@@ -2,16 +2,21 @@ package crdparser
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 )
 
+var ErrPrimaryCRDNotFound = errors.New("primary CRD not found")
+
 type FileSystem interface {
 	ReadFile(path string) ([]byte, error)
 }
@@ -42,9 +47,18 @@ type Resource struct {
 	} `yaml:"spec"`
 }
 
-func (s *Service) IsCRDClusterScoped(paths *types.ResourcePaths) (bool, error) {
+// IsCRDClusterScoped determines whether the primary CRD of the module is cluster-scoped. The primary CRD is taken
+// from the module config if configured, otherwise from the kind of the default CR. If neither is available, it is
+// inferred from the manifest, see inferPrimaryCRD.
+func (s *Service) IsCRDClusterScoped(paths *types.ResourcePaths,
+	moduleConfig *contentprovider.ModuleConfig,
+) (bool, error) {
+	if moduleConfig != nil && moduleConfig.PrimaryCRD != nil {
+		return s.isPrimaryCRDClusterScoped(paths.RawManifest, moduleConfig.PrimaryCRD)
+	}
+
 	if paths.DefaultCR == "" {
-		return false, nil
+		return s.isInferredCRDClusterScoped(paths.RawManifest, moduleConfig)
 	}
 
 	crData, err := s.fileSystem.ReadFile(paths.DefaultCR)
@@ -72,6 +86,48 @@ func (s *Service) IsCRDClusterScoped(paths *types.ResourcePaths) (bool, error) {
 	return crdScope == apiextensions.ClusterScoped, nil
 }
 
+func (s *Service) isPrimaryCRDClusterScoped(manifestPath string, primaryCRD *metav1.GroupKind) (bool, error) {
+	manifestData, err := s.fileSystem.ReadFile(manifestPath)
+	if err != nil {
+		return false, fmt.Errorf("error reading manifest file: %w", err)
+	}
+
+	crdScope, err := getCrdScopeFromManifest(manifestData, primaryCRD.Group, primaryCRD.Kind)
+	if err != nil {
+		return false, fmt.Errorf("error finding CRD file in the %q file: %w", manifestPath, err)
+	}
+	if crdScope == "" {
+		return false, fmt.Errorf("primary CRD %s.%s not found in the %q file: %w",
+			primaryCRD.Kind, primaryCRD.Group, manifestPath, ErrPrimaryCRDNotFound)
+	}
+
+	return crdScope == apiextensions.ClusterScoped, nil
+}
+
+func (s *Service) isInferredCRDClusterScoped(manifestPath string,
+	moduleConfig *contentprovider.ModuleConfig,
+) (bool, error) {
+	manifestData, err := s.fileSystem.ReadFile(manifestPath)
+	if err != nil {
+		return false, fmt.Errorf("error reading manifest file: %w", err)
+	}
+
+	var manager *contentprovider.Manager
+	if moduleConfig != nil {
+		manager = moduleConfig.Manager
+	}
+
+	crd, found, err := inferPrimaryCRD(manifestData, manager)
+	if err != nil {
+		return false, fmt.Errorf("error inferring primary CRD from the %q file: %w", manifestPath, err)
+	}
+	if !found {
+		return false, nil
+	}
+
+	return crd.Spec.Scope == apiextensions.ClusterScoped, nil
+}
+
 func getCrdScopeFromManifest(manifestData []byte, group, kind string) (apiextensions.ResourceScope, error) {
 	decoder := yaml.NewDecoder(bytes.NewReader(manifestData))
 
@@ -85,7 +141,7 @@ func getCrdScopeFromManifest(manifestData []byte, group, kind string) (apiextens
 			return "", fmt.Errorf("failed to parse YAML document: %w", err)
 		}
 
-		if res.Kind == "CustomResourceDefinition" && res.Spec.Group == group && res.Spec.Names.Kind == kind {
+		if res.Kind == kindCRD && res.Spec.Group == group && res.Spec.Names.Kind == kind {
 			return res.Spec.Scope, nil
 		}
 	}
@@ -10,8 +10,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 	"github.com/kyma-project/modulectl/internal/service/crdparser"
 )
 
@@ -30,7 +32,7 @@ func TestService_IsCRDClusterScoped_ReturnsTrueWhenClusterScoped(t *testing.T) {
 	crdParserService, _ := crdparser.NewService(&fileSystemClusterScopedExistsStub{})
 
 	resourcePaths := types.NewResourcePaths(defaultCRPath, rawManifestPath, "")
-	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths)
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, nil)
 	require.NoError(t, err)
 	require.True(t, isClusterScoped)
 }
@@ -39,7 +41,7 @@ func TestService_IsCRDClusterScoped_ReturnsFalseWhenNamespaceScoped(t *testing.T
 	crdParserService, _ := crdparser.NewService(&fileSystemNamespacedScopedExistsStub{})
 
 	resourcePaths := types.NewResourcePaths(defaultCRPath, rawManifestPath, "")
-	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths)
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, nil)
 	require.NoError(t, err)
 	require.False(t, isClusterScoped)
 }
@@ -48,10 +50,184 @@ func TestService_IsCRDClusterScoped_ReturnsErrorWhenFileReadingRetrievalError(t
 	crdParserService, _ := crdparser.NewService(&fileSystemNotExistStub{})
 
 	resourcePaths := types.NewResourcePaths(defaultCRPath, rawManifestPath, "")
-	_, err := crdParserService.IsCRDClusterScoped(resourcePaths)
+	_, err := crdParserService.IsCRDClusterScoped(resourcePaths, nil)
 	require.ErrorContains(t, err, "error reading default CR file")
 }
 
+func TestService_IsCRDClusterScoped_UsesConfiguredPrimaryCRD(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&fileSystemClusterScopedExistsStub{})
+	moduleConfig := &contentprovider.ModuleConfig{
+		PrimaryCRD: &metav1.GroupKind{Group: "operator.kyma-project.io", Kind: "Managed"},
+	}
+
+	resourcePaths := types.NewResourcePaths(defaultCRPath, rawManifestPath, "")
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, moduleConfig)
+	require.NoError(t, err)
+	require.False(t, isClusterScoped)
+}
+
+func TestService_IsCRDClusterScoped_UsesConfiguredPrimaryCRDWithoutDefaultCR(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&fileSystemNamespacedScopedExistsStub{})
+	moduleConfig := &contentprovider.ModuleConfig{
+		PrimaryCRD: &metav1.GroupKind{Group: "operator.kyma-project.io", Kind: "Managed"},
+	}
+
+	resourcePaths := types.NewResourcePaths("", rawManifestPath, "")
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, moduleConfig)
+	require.NoError(t, err)
+	require.True(t, isClusterScoped)
+}
+
+func TestService_IsCRDClusterScoped_ReturnsErrorWhenConfiguredPrimaryCRDNotFound(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&fileSystemClusterScopedExistsStub{})
+	moduleConfig := &contentprovider.ModuleConfig{
+		PrimaryCRD: &metav1.GroupKind{Group: "operator.kyma-project.io", Kind: "Unknown"},
+	}
+
+	resourcePaths := types.NewResourcePaths("", rawManifestPath, "")
+	_, err := crdParserService.IsCRDClusterScoped(resourcePaths, moduleConfig)
+	require.ErrorIs(t, err, crdparser.ErrPrimaryCRDNotFound)
+}
+
+func TestService_IsCRDClusterScoped_InfersSingleCRDWithoutDefaultCR(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&manifestStub{manifest: clusterScopedCRD})
+
+	resourcePaths := types.NewResourcePaths("", rawManifestPath, "")
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, &contentprovider.ModuleConfig{})
+	require.NoError(t, err)
+	require.True(t, isClusterScoped)
+}
+
+func TestService_IsCRDClusterScoped_ReturnsFalseWhenPrimaryCRDIsAmbiguous(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&fileSystemClusterScopedExistsStub{})
+
+	resourcePaths := types.NewResourcePaths("", rawManifestPath, "")
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, &contentprovider.ModuleConfig{})
+	require.NoError(t, err)
+	require.False(t, isClusterScoped)
+}
+
+func TestService_IsCRDClusterScoped_InfersCRDReconciledByManager(t *testing.T) {
+	manifest := strings.Join([]string{namespacedCRD, clusterScopedCRD, managerDeployment, managerRBAC}, "\n---\n")
+	crdParserService, _ := crdparser.NewService(&manifestStub{manifest: manifest})
+	moduleConfig := &contentprovider.ModuleConfig{
+		Manager: &contentprovider.Manager{
+			Name:             "sample-manager",
+			GroupVersionKind: metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+	}
+
+	resourcePaths := types.NewResourcePaths("", rawManifestPath, "")
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, moduleConfig)
+	require.NoError(t, err)
+	require.True(t, isClusterScoped)
+}
+
+func TestService_IsCRDClusterScoped_IgnoresUnrelatedDocumentsWithUnexpectedShape(t *testing.T) {
+	manifest := strings.Join([]string{
+		namespacedCRD, clusterScopedCRD, managerDeployment, managerRBAC, unrelatedResource,
+	}, "\n---\n")
+	crdParserService, _ := crdparser.NewService(&manifestStub{manifest: manifest})
+	moduleConfig := &contentprovider.ModuleConfig{
+		Manager: &contentprovider.Manager{
+			Name:             "sample-manager",
+			GroupVersionKind: metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+	}
+
+	resourcePaths := types.NewResourcePaths("", rawManifestPath, "")
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, moduleConfig)
+	require.NoError(t, err)
+	require.True(t, isClusterScoped)
+}
+
+func TestService_IsCRDClusterScoped_IgnoresRulesOfServiceAccountInOtherNamespace(t *testing.T) {
+	manifest := strings.Join([]string{namespacedCRD, clusterScopedCRD, managerDeployment, managerRBAC}, "\n---\n")
+	crdParserService, _ := crdparser.NewService(&manifestStub{manifest: manifest})
+	moduleConfig := &contentprovider.ModuleConfig{
+		Manager: &contentprovider.Manager{
+			Name:             "sample-manager",
+			Namespace:        "other-system",
+			GroupVersionKind: metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+	}
+
+	resourcePaths := types.NewResourcePaths("", rawManifestPath, "")
+	isClusterScoped, err := crdParserService.IsCRDClusterScoped(resourcePaths, moduleConfig)
+	require.NoError(t, err)
+	require.False(t, isClusterScoped, "the primary CRD must be ambiguous without the rules of the manager")
+}
+
+const (
+	unrelatedResource = `apiVersion: example.com/v1
+kind: Policy
+metadata:
+  name: unrelated
+spec:
+  - group: example.com
+rules: allow-all`
+	namespacedCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: configs.operator.kyma-project.io
+spec:
+  group: operator.kyma-project.io
+  names:
+    kind: Config
+    plural: configs
+  scope: Namespaced`
+	clusterScopedCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: samples.operator.kyma-project.io
+spec:
+  group: operator.kyma-project.io
+  names:
+    kind: Sample
+    plural: samples
+  scope: Cluster`
+	managerDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: sample-manager
+spec:
+  template:
+    spec:
+      serviceAccountName: sample-manager`
+	managerRBAC = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: sample-manager-role
+rules:
+- apiGroups: ["operator.kyma-project.io"]
+  resources: ["samples", "configs"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: ["operator.kyma-project.io"]
+  resources: ["samples/status"]
+  verbs: ["get", "update", "patch"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: sample-manager-rolebinding
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: sample-manager-role
+subjects:
+- kind: ServiceAccount
+  name: sample-manager
+  namespace: kyma-system`
+)
+
+type manifestStub struct {
+	manifest string
+}
+
+func (m *manifestStub) ReadFile(_ string) ([]byte, error) {
+	return []byte(m.manifest), nil
+}
+
 type fileSystemClusterScopedExistsStub struct{}
 
 func (*fileSystemClusterScopedExistsStub) ReadFile(path string) ([]byte, error) {
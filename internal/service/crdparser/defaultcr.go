@@ -0,0 +1,117 @@
+package crdparser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+
+	"github.com/kyma-project/modulectl/internal/common/types"
+)
+
+var (
+	ErrDefaultCRWithoutCRD       = errors.New("no CRD found in the manifest for the default CR")
+	ErrDefaultCRVersionNotServed = errors.New("default CR version is not served by its CRD")
+	ErrDefaultCRInvalidScope     = errors.New("default CR does not match the scope of its CRD")
+)
+
+type defaultCRDocument struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+// ValidateDefaultCRs validates every document of the default CR file against its CRD in the manifest. The CRD must
+// exist, serve the version of the CR, and a CR of a cluster-scoped CRD must not set a namespace.
+func (s *Service) ValidateDefaultCRs(paths *types.ResourcePaths) error {
+	if paths.DefaultCR == "" {
+		return nil
+	}
+
+	crData, err := s.fileSystem.ReadFile(paths.DefaultCR)
+	if err != nil {
+		return fmt.Errorf("error reading default CR file: %w", err)
+	}
+
+	customResources, err := decodeDefaultCRDocuments(crData)
+	if err != nil {
+		return fmt.Errorf("error parsing default CR: %w", err)
+	}
+
+	manifestData, err := s.fileSystem.ReadFile(paths.RawManifest)
+	if err != nil {
+		return fmt.Errorf("error reading manifest file: %w", err)
+	}
+
+	documents, err := decodeManifestDocuments(manifestData, nil)
+	if err != nil {
+		return fmt.Errorf("error parsing manifest file %q: %w", paths.RawManifest, err)
+	}
+
+	for _, customResource := range customResources {
+		if err := validateDefaultCR(customResource, documents); err != nil {
+			return fmt.Errorf("invalid default CR %s %q: %w", customResource.Kind, customResource.Metadata.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func decodeDefaultCRDocuments(crData []byte) ([]defaultCRDocument, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(crData))
+
+	var customResources []defaultCRDocument
+	for {
+		var customResource defaultCRDocument
+		if err := decoder.Decode(&customResource); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse YAML document: %w", err)
+		}
+		if customResource.APIVersion == "" && customResource.Kind == "" {
+			continue
+		}
+		customResources = append(customResources, customResource)
+	}
+	return customResources, nil
+}
+
+func validateDefaultCR(customResource defaultCRDocument, documents []manifestDocument) error {
+	group, version, _ := strings.Cut(customResource.APIVersion, "/")
+
+	for _, crd := range documents {
+		if crd.Kind != kindCRD || crd.Spec.Group != group || crd.Spec.Names.Kind != customResource.Kind {
+			continue
+		}
+
+		if !crdServesVersion(crd, version) {
+			return fmt.Errorf("version %q of CRD %q: %w", version, crd.Metadata.Name, ErrDefaultCRVersionNotServed)
+		}
+
+		if crd.Spec.Scope == apiextensions.ClusterScoped && customResource.Metadata.Namespace != "" {
+			return fmt.Errorf("namespace %q set for cluster-scoped CRD %q: %w",
+				customResource.Metadata.Namespace, crd.Metadata.Name, ErrDefaultCRInvalidScope)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", customResource.APIVersion, ErrDefaultCRWithoutCRD)
+}
+
+func crdServesVersion(crd manifestDocument, version string) bool {
+	for _, crdVersion := range crd.Spec.Versions {
+		if crdVersion.Name == version && crdVersion.Served {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package crdparser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/crdparser"
+)
+
+const validationManifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: samples.operator.kyma-project.io
+spec:
+  group: operator.kyma-project.io
+  names:
+    kind: Sample
+    plural: samples
+  scope: Cluster
+  versions:
+  - name: v1alpha1
+    served: false
+  - name: v1
+    served: true
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: configs.operator.kyma-project.io
+spec:
+  group: operator.kyma-project.io
+  names:
+    kind: Config
+    plural: configs
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true`
+
+func TestService_ValidateDefaultCRs(t *testing.T) {
+	tests := []struct {
+		name      string
+		defaultCR string
+		wantErr   error
+	}{
+		{
+			name: "single default CR",
+			defaultCR: `apiVersion: operator.kyma-project.io/v1
+kind: Sample
+metadata:
+  name: default`,
+		},
+		{
+			name: "multiple default CRs",
+			defaultCR: `---
+apiVersion: operator.kyma-project.io/v1
+kind: Sample
+metadata:
+  name: default
+---
+apiVersion: operator.kyma-project.io/v1
+kind: Config
+metadata:
+  name: config
+  namespace: kyma-system
+---`,
+		},
+		{
+			name: "default CR without CRD",
+			defaultCR: `apiVersion: operator.kyma-project.io/v1
+kind: Sample
+metadata:
+  name: default
+---
+apiVersion: operator.kyma-project.io/v1
+kind: Unknown
+metadata:
+  name: unknown`,
+			wantErr: crdparser.ErrDefaultCRWithoutCRD,
+		},
+		{
+			name: "default CR with version not served",
+			defaultCR: `apiVersion: operator.kyma-project.io/v1alpha1
+kind: Sample
+metadata:
+  name: default`,
+			wantErr: crdparser.ErrDefaultCRVersionNotServed,
+		},
+		{
+			name: "default CR with namespace for cluster-scoped CRD",
+			defaultCR: `apiVersion: operator.kyma-project.io/v1
+kind: Sample
+metadata:
+  name: default
+  namespace: kyma-system`,
+			wantErr: crdparser.ErrDefaultCRInvalidScope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crdParserService, _ := crdparser.NewService(&defaultCRFileSystemStub{
+				defaultCR: tt.defaultCR,
+				manifest:  validationManifest,
+			})
+
+			err := crdParserService.ValidateDefaultCRs(types.NewResourcePaths(defaultCRPath, rawManifestPath, ""))
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_ValidateDefaultCRs_SkipsWhenNoDefaultCR(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&fileSystemNotExistStub{})
+
+	err := crdParserService.ValidateDefaultCRs(types.NewResourcePaths("", rawManifestPath, ""))
+
+	require.NoError(t, err)
+}
+
+type defaultCRFileSystemStub struct {
+	defaultCR string
+	manifest  string
+}
+
+func (s *defaultCRFileSystemStub) ReadFile(path string) ([]byte, error) {
+	if strings.Contains(path, "defaultCR") {
+		return []byte(s.defaultCR), nil
+	}
+	return []byte(s.manifest), nil
+}
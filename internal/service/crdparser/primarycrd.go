@@ -0,0 +1,247 @@
+package crdparser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+)
+
+const (
+	kindCRD                = "CustomResourceDefinition"
+	kindRoleBinding        = "RoleBinding"
+	kindClusterRoleBinding = "ClusterRoleBinding"
+	kindRole               = "Role"
+	kindClusterRole        = "ClusterRole"
+	kindServiceAccount     = "ServiceAccount"
+	defaultServiceAccount  = "default"
+	statusSubresource      = "/status"
+	wildcard               = "*"
+)
+
+type policyRule struct {
+	APIGroups []string `yaml:"apiGroups"`
+	Resources []string `yaml:"resources"`
+	Verbs     []string `yaml:"verbs"`
+}
+
+type subject struct {
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+type metadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+// manifestHeader holds the fields every manifest document is decoded into, to decide whether the document is
+// decoded completely.
+type manifestHeader struct {
+	Kind     string   `yaml:"kind"`
+	Metadata metadata `yaml:"metadata"`
+}
+
+// manifestDocument holds the fields of CRDs, workloads and RBAC resources needed to infer the primary CRD.
+type manifestDocument struct {
+	Kind     string   `yaml:"kind"`
+	Metadata metadata `yaml:"metadata"`
+	Spec     struct {
+		Group string `yaml:"group"`
+		Names struct {
+			Kind   string `yaml:"kind"`
+			Plural string `yaml:"plural"`
+		} `yaml:"names"`
+		Scope    apiextensions.ResourceScope `yaml:"scope"`
+		Versions []struct {
+			Name   string `yaml:"name"`
+			Served bool   `yaml:"served"`
+		} `yaml:"versions"`
+		Template struct {
+			Spec struct {
+				ServiceAccountName string `yaml:"serviceAccountName"`
+			} `yaml:"spec"`
+		} `yaml:"template"`
+	} `yaml:"spec"`
+	Rules   []policyRule `yaml:"rules"`
+	RoleRef struct {
+		Kind string `yaml:"kind"`
+		Name string `yaml:"name"`
+	} `yaml:"roleRef"`
+	Subjects []subject `yaml:"subjects"`
+}
+
+// inferPrimaryCRD infers the primary CRD of a module from its manifest. If a manager is configured, the CRDs whose
+// status the manager is allowed to update are considered its reconciled kinds; if this yields exactly one CRD,
+// it is the primary CRD. Otherwise, the manifest's only CRD is used. If no unique CRD can be found, false is returned.
+func inferPrimaryCRD(manifestData []byte, manager *contentprovider.Manager) (manifestDocument, bool, error) {
+	documents, err := decodeManifestDocuments(manifestData, manager)
+	if err != nil {
+		return manifestDocument{}, false, err
+	}
+
+	var crds []manifestDocument
+	for _, doc := range documents {
+		if doc.Kind == kindCRD {
+			crds = append(crds, doc)
+		}
+	}
+
+	if manager != nil {
+		reconciled := reconciledCRDs(documents, crds, manager)
+		if len(reconciled) == 1 {
+			return reconciled[0], true, nil
+		}
+	}
+
+	if len(crds) == 1 {
+		return crds[0], true, nil
+	}
+
+	return manifestDocument{}, false, nil
+}
+
+// decodeManifestDocuments decodes the CRDs, RBAC resources and the manager workload of the manifest. Other documents
+// only have their kind and metadata decoded, so resources with unexpected shapes do not fail the parsing.
+func decodeManifestDocuments(manifestData []byte, manager *contentprovider.Manager) ([]manifestDocument, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(manifestData))
+
+	var documents []manifestDocument
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse YAML document: %w", err)
+		}
+
+		var header manifestHeader
+		if err := node.Decode(&header); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML document: %w", err)
+		}
+		doc := manifestDocument{Kind: header.Kind, Metadata: header.Metadata}
+		if isDecodedCompletely(header, manager) {
+			if err := node.Decode(&doc); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %q: %w", header.Kind, header.Metadata.Name, err)
+			}
+		}
+		documents = append(documents, doc)
+	}
+	return documents, nil
+}
+
+func isDecodedCompletely(header manifestHeader, manager *contentprovider.Manager) bool {
+	switch header.Kind {
+	case kindCRD, kindRole, kindClusterRole, kindRoleBinding, kindClusterRoleBinding:
+		return true
+	}
+	return manager != nil && header.Kind == manager.Kind && header.Metadata.Name == manager.Name
+}
+
+// reconciledCRDs returns the CRDs whose status subresource may be updated by the service account of the manager.
+func reconciledCRDs(documents, crds []manifestDocument, manager *contentprovider.Manager) []manifestDocument {
+	serviceAccount, found := managerServiceAccount(documents, manager)
+	if !found {
+		return nil
+	}
+
+	rules := serviceAccountRules(documents, serviceAccount)
+
+	var reconciled []manifestDocument
+	for _, crd := range crds {
+		if rulesAllowStatusUpdate(rules, crd.Spec.Group, crd.Spec.Names.Plural) {
+			reconciled = append(reconciled, crd)
+		}
+	}
+	return reconciled
+}
+
+// managerServiceAccount returns the service account of the manager workload. Its namespace is taken from the manager
+// config or, if not set, from the workload and is empty if neither is set.
+func managerServiceAccount(documents []manifestDocument, manager *contentprovider.Manager) (subject, bool) {
+	for _, doc := range documents {
+		if doc.Kind == manager.Kind && doc.Metadata.Name == manager.Name {
+			serviceAccount := subject{
+				Kind:      kindServiceAccount,
+				Name:      doc.Spec.Template.Spec.ServiceAccountName,
+				Namespace: manager.Namespace,
+			}
+			if serviceAccount.Name == "" {
+				serviceAccount.Name = defaultServiceAccount
+			}
+			if serviceAccount.Namespace == "" {
+				serviceAccount.Namespace = doc.Metadata.Namespace
+			}
+			return serviceAccount, true
+		}
+	}
+	return subject{}, false
+}
+
+// serviceAccountRules returns the rules of the roles bound to the service account. Subjects are matched by name and
+// namespace, the namespace of a subject defaults to the namespace of its RoleBinding. If the namespace of the
+// service account is unknown, subjects are matched by name only.
+func serviceAccountRules(documents []manifestDocument, serviceAccount subject) []policyRule {
+	var rules []policyRule
+	for _, binding := range documents {
+		if binding.Kind != kindRoleBinding && binding.Kind != kindClusterRoleBinding {
+			continue
+		}
+		if !slices.ContainsFunc(binding.Subjects, func(s subject) bool {
+			if s.Namespace == "" && binding.Kind == kindRoleBinding {
+				s.Namespace = binding.Metadata.Namespace
+			}
+			return s.Kind == kindServiceAccount && s.Name == serviceAccount.Name &&
+				(serviceAccount.Namespace == "" || s.Namespace == serviceAccount.Namespace)
+		}) {
+			continue
+		}
+		for _, role := range documents {
+			if !isBoundRole(role, binding) {
+				continue
+			}
+			rules = append(rules, role.Rules...)
+		}
+	}
+	return rules
+}
+
+// isBoundRole returns true if the role is referenced by the binding. Roles must be in the namespace of the
+// RoleBinding.
+func isBoundRole(role, binding manifestDocument) bool {
+	if role.Kind != binding.RoleRef.Kind || role.Metadata.Name != binding.RoleRef.Name {
+		return false
+	}
+	switch role.Kind {
+	case kindClusterRole:
+		return true
+	case kindRole:
+		return binding.Kind == kindRoleBinding && role.Metadata.Namespace == binding.Metadata.Namespace
+	default:
+		return false
+	}
+}
+
+func rulesAllowStatusUpdate(rules []policyRule, group, plural string) bool {
+	for _, rule := range rules {
+		if !slices.Contains(rule.APIGroups, group) && !slices.Contains(rule.APIGroups, wildcard) {
+			continue
+		}
+		if !slices.Contains(rule.Resources, plural+statusSubresource) && !slices.Contains(rule.Resources, wildcard) {
+			continue
+		}
+		if slices.Contains(rule.Verbs, "update") || slices.Contains(rule.Verbs, "patch") ||
+			slices.Contains(rule.Verbs, wildcard) {
+			return true
+		}
+	}
+	return false
+}
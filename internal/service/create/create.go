@@ -1,21 +1,56 @@
 package create
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
 	"path"
 	"strconv"
+	"strings"
+	"syscall"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/kyma-project/lifecycle-manager/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kyma-project/modulectl/internal/common"
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
+	"github.com/kyma-project/modulectl/internal/service/checksum"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/git"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/variables"
+	"github.com/kyma-project/modulectl/tools/download"
+)
+
+const (
+	manifestFilePattern  = "kyma-module-manifest-*.yaml"
+	defaultCRFilePattern = "kyma-module-default-cr-*.yaml"
+	// manifestFileName and defaultCRFileName are the names of the remote or expanded manifest and default CR in the
+	// work dir, referenced by the component constructor.
+	manifestFileName  = "raw-manifest.yaml"
+	defaultCRFileName = "default-cr.yaml"
+
+	originRemoteName = "origin"
+)
+
+var (
+	ErrUnknownAssociatedResources = errors.New("unknown associated resources")
+	ErrDirtyWorkingTree           = errors.New("git working tree has uncommitted changes")
+	ErrVersionTagMismatch         = errors.New("module version does not match a version tag")
+	ErrInterrupted                = errors.New("interrupted by signal")
 )
 
 type ModuleConfigService interface {
-	ParseAndValidateModuleConfig(moduleConfigFile string) (*contentprovider.ModuleConfig, error)
+	ParseAndValidateModuleConfig(moduleConfigFile string,
+		opts moduleconfigreader.Options,
+	) (*contentprovider.ModuleConfig, error)
+	GetEffectiveModuleConfig(moduleConfigFile string, opts moduleconfigreader.Options) (string, error)
+	CleanupTempFiles() []error
 }
 
 type FileSystem interface {
@@ -29,8 +64,36 @@ type FileResolver interface {
 	CleanupTempFiles() []error
 }
 
+type GitService interface {
+	GetLatestCommit(gitRepoPath string) (string, error)
+	GetUncommittedChanges(gitRepoPath string) ([]string, error)
+	GetRemoteRepositoryURL(gitRepoPath, remoteName string) (string, error)
+	GetMetadata(gitRepoPath string) (*types.GitMetadata, error)
+}
+
+type VariableService interface {
+	ExpandFile(filePath, pattern string, vars *variables.Variables) (string, error)
+}
+
+type ChecksumService interface {
+	Verify(filePath, source, expected string) (string, error)
+	LoadLockFile(path string) (*checksum.LockFile, error)
+	SaveLockFile(path string, lockFile *checksum.LockFile) error
+}
+
+type DownloadService interface {
+	Configure(settings download.Settings)
+}
+
+type WorkDirService interface {
+	Init(dir string, keep bool) (string, error)
+	Persist(filePath, name string) (string, error)
+	Finish(success bool) error
+}
+
 type GitSourcesService interface {
 	AddGitSourcesToConstructor(constructor *component.Constructor, gitRepoPath, gitRepoURL string) error
+	AddGitSourceWithCommit(constructor *component.Constructor, gitRepoURL, commit string)
 }
 
 type ComponentConstructorService interface {
@@ -43,8 +106,20 @@ type ComponentConstructorService interface {
 	CreateConstructorFile(componentConstructor *component.Constructor,
 		outputFile string,
 	) error
+	BundleFileInputs(componentConstructor *component.Constructor, bundleDir string) error
+	RelativizeInputPaths(componentConstructor *component.Constructor, constructorFile string) error
 	SetComponentLabel(componentConstructor *component.Constructor, name, value string)
 	SetResponsiblesLabel(componentConstructor *component.Constructor, team string)
+	SetResponsibles(componentConstructor *component.Constructor, responsibles []component.ResponsibleEntry)
+	AddLabels(componentConstructor *component.Constructor, componentLabels []component.Label,
+		resourceLabels, sourceLabels map[string][]component.Label) error
+	AddComponentReferences(componentConstructor *component.Constructor,
+		references []component.ComponentReference,
+	) error
+}
+
+type ComponentVerifierService interface {
+	VerifyComponentVersion(registry, componentName, version string) error
 }
 
 type ModuleTemplateService interface {
@@ -56,13 +131,20 @@ type ModuleTemplateService interface {
 }
 
 type CRDParserService interface {
-	IsCRDClusterScoped(paths *types.ResourcePaths) (bool, error)
+	IsCRDClusterScoped(paths *types.ResourcePaths, moduleConfig *contentprovider.ModuleConfig) (bool, error)
+	ValidateDefaultCRs(paths *types.ResourcePaths) error
 }
 
 type ImageVersionVerifierService interface {
 	VerifyModuleResources(moduleConfig *contentprovider.ModuleConfig, filePath string) error
 }
 
+type AssociatedResourcesVerifierService interface {
+	VerifyAssociatedResources(moduleConfig *contentprovider.ModuleConfig,
+		filePath string,
+	) ([]*metav1.GroupVersionKind, error)
+}
+
 type ManifestService interface {
 	ExtractImagesFromManifest(manifestPath string) ([]string, error)
 }
@@ -74,10 +156,18 @@ type Service struct {
 	moduleTemplateService       ModuleTemplateService
 	crdParserService            CRDParserService
 	imageVersionVerifierService ImageVersionVerifierService
+	associatedResourcesVerifier AssociatedResourcesVerifierService
 	manifestService             ManifestService
 	manifestFileResolver        FileResolver
 	defaultCRFileResolver       FileResolver
+	resourceFileResolver        FileResolver
 	fileSystem                  FileSystem
+	gitService                  GitService
+	variableService             VariableService
+	checksumService             ChecksumService
+	downloadService             DownloadService
+	workDirService              WorkDirService
+	componentVerifier           ComponentVerifierService
 }
 
 func NewService(moduleConfigService ModuleConfigService,
@@ -86,10 +176,18 @@ func NewService(moduleConfigService ModuleConfigService,
 	moduleTemplateService ModuleTemplateService,
 	crdParserService CRDParserService,
 	imageVersionVerifierService ImageVersionVerifierService,
+	associatedResourcesVerifier AssociatedResourcesVerifierService,
 	manifestService ManifestService,
 	manifestFileResolver FileResolver,
 	defaultCRFileResolver FileResolver,
+	resourceFileResolver FileResolver,
 	fileSystem FileSystem,
+	gitService GitService,
+	variableService VariableService,
+	checksumService ChecksumService,
+	downloadService DownloadService,
+	workDirService WorkDirService,
+	componentVerifier ComponentVerifierService,
 ) (*Service, error) {
 	if moduleConfigService == nil {
 		return nil, fmt.Errorf("moduleConfigService must not be nil: %w", commonerrors.ErrInvalidArg)
@@ -114,6 +212,11 @@ func NewService(moduleConfigService ModuleConfigService,
 	if imageVersionVerifierService == nil {
 		return nil, fmt.Errorf("imageVersionVerifierService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
+
+	if associatedResourcesVerifier == nil {
+		return nil, fmt.Errorf("associatedResourcesVerifier must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
 	if manifestService == nil {
 		return nil, fmt.Errorf("manifestService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
@@ -126,10 +229,38 @@ func NewService(moduleConfigService ModuleConfigService,
 		return nil, fmt.Errorf("defaultCRFileResolver must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
+	if resourceFileResolver == nil {
+		return nil, fmt.Errorf("resourceFileResolver must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
 	if fileSystem == nil {
 		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
+	if gitService == nil {
+		return nil, fmt.Errorf("gitService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if variableService == nil {
+		return nil, fmt.Errorf("variableService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if checksumService == nil {
+		return nil, fmt.Errorf("checksumService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if downloadService == nil {
+		return nil, fmt.Errorf("downloadService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if workDirService == nil {
+		return nil, fmt.Errorf("workDirService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if componentVerifier == nil {
+		return nil, fmt.Errorf("componentVerifier must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
 	return &Service{
 		moduleConfigService:         moduleConfigService,
 		gitSourcesService:           gitSourcesService,
@@ -137,10 +268,18 @@ func NewService(moduleConfigService ModuleConfigService,
 		moduleTemplateService:       moduleTemplateService,
 		crdParserService:            crdParserService,
 		imageVersionVerifierService: imageVersionVerifierService,
+		associatedResourcesVerifier: associatedResourcesVerifier,
 		manifestService:             manifestService,
 		manifestFileResolver:        manifestFileResolver,
 		defaultCRFileResolver:       defaultCRFileResolver,
+		resourceFileResolver:        resourceFileResolver,
 		fileSystem:                  fileSystem,
+		gitService:                  gitService,
+		variableService:             variableService,
+		checksumService:             checksumService,
+		downloadService:             downloadService,
+		workDirService:              workDirService,
+		componentVerifier:           componentVerifier,
 	}, nil
 }
 
@@ -149,17 +288,93 @@ func (s *Service) Run(opts Options) (rErr error) { //nolint:nonamedreturns // na
 		return err
 	}
 
-	moduleConfig, err := s.moduleConfigService.ParseAndValidateModuleConfig(opts.ConfigFile)
+	// An interrupted run cancels the running downloads and stops at the next step. It returns an error instead of
+	// exiting, so that the files created by the run are cleaned up. The signal handling is reset after the first
+	// signal, so that a second signal terminates the process.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	s.downloadService.Configure(download.Settings{
+		Offline: opts.Offline,
+		Timeout: opts.DownloadTimeout,
+		Retries: opts.DownloadRetries,
+		Context: ctx,
+	})
+
+	vars, err := s.newVariables(opts)
 	if err != nil {
-		return fmt.Errorf("failed to parse module config: %w", err)
+		return fmt.Errorf("failed to set up variables: %w", err)
+	}
+	configOpts := moduleconfigreader.Options{
+		Variables:          vars,
+		Profile:            opts.Profile,
+		AllowUnknownFields: opts.AllowUnknownFields,
+		Out:                opts.Out,
+	}
+	if opts.VersionFromTag {
+		if configOpts.Version, err = s.versionFromTag(opts); err != nil {
+			return fmt.Errorf("failed to derive module version from git tag: %w", err)
+		}
 	}
 
+	if opts.PrintEffectiveConfig {
+		defer s.cleanupModuleConfigTempFiles(opts)
+		effectiveConfig, err := s.moduleConfigService.GetEffectiveModuleConfig(opts.ConfigFile, configOpts)
+		if err != nil {
+			return fmt.Errorf("failed to get effective module config: %w", err)
+		}
+		opts.Out.Write(effectiveConfig)
+		return nil
+	}
+
+	if err := s.verifyWorkingTree(opts); err != nil {
+		return err
+	}
+
+	workDir, err := s.workDirService.Init(opts.WorkDir, opts.KeepWorkDir)
+	if err != nil {
+		return fmt.Errorf("failed to initialize work dir: %w", err)
+	}
 	defer func() {
-		if rErr != nil { // only clean up if an error occurs
+		// The temporary files are removed before the work dir is finished, which removes the temporary files dir.
+		s.cleanupModuleConfigTempFiles(opts)
+		if rErr != nil {
 			s.cleanupTempFiles(opts)
 		}
+		if err := s.workDirService.Finish(rErr == nil); err != nil {
+			opts.Out.Write(fmt.Sprintf("failed to cleanup work dir: %v\n", err))
+		}
+		if opts.KeepWorkDir {
+			opts.Out.Write(fmt.Sprintf("- Keeping work dir %s\n", workDir))
+		}
 	}()
 
+	moduleConfig, err := s.moduleConfigService.ParseAndValidateModuleConfig(opts.ConfigFile, configOpts)
+	if err != nil {
+		return fmt.Errorf("failed to parse module config: %w", err)
+	}
+	if err = checkInterrupted(ctx); err != nil {
+		return err
+	}
+
+	if opts.RequireVersionTag && !opts.VersionFromTag {
+		if err = s.verifyVersionTag(moduleConfig, opts); err != nil {
+			return fmt.Errorf("failed to verify module version: %w", err)
+		}
+	}
+
+	if err = s.resolveRepository(moduleConfig, opts); err != nil {
+		return fmt.Errorf("failed to resolve repository: %w", err)
+	}
+
+	if err = s.verifyComponentReferences(moduleConfig, opts); err != nil {
+		return fmt.Errorf("failed to verify component references: %w", err)
+	}
+	if err = checkInterrupted(ctx); err != nil {
+		return err
+	}
+
 	configFilePath := path.Dir(opts.ConfigFile)
 	// If the manifest is a local file reference, it's entry in the module config file will be relative to the module
 	// config file location (usually the same directory).
@@ -178,7 +393,49 @@ func (s *Service) Run(opts Options) (rErr error) { //nolint:nonamedreturns // na
 		}
 	}
 
+	if err = s.verifyChecksums(moduleConfig, manifestFilePath, defaultCRFilePath, opts); err != nil {
+		return fmt.Errorf("failed to verify checksums: %w", err)
+	}
+	if err = checkInterrupted(ctx); err != nil {
+		return err
+	}
+
+	if vars != nil {
+		vars.SetDefault(variables.ModuleNameVariable, moduleConfig.Name)
+		vars.SetDefault(variables.ModuleVersionVariable, moduleConfig.Version)
+
+		manifestFilePath, err = s.variableService.ExpandFile(manifestFilePath, manifestFilePattern, vars)
+		if err != nil {
+			return fmt.Errorf("failed to expand variables in manifest file: %w", err)
+		}
+
+		if defaultCRFilePath != "" {
+			defaultCRFilePath, err = s.variableService.ExpandFile(defaultCRFilePath, defaultCRFilePattern, vars)
+			if err != nil {
+				return fmt.Errorf("failed to expand variables in default CR file: %w", err)
+			}
+		}
+	}
+
+	// Remote and expanded files are temporary, the component constructor must reference stable copies of them.
+	if moduleConfig.Manifest.IsURL() || vars != nil {
+		if manifestFilePath, err = s.workDirService.Persist(manifestFilePath, manifestFileName); err != nil {
+			return fmt.Errorf("failed to persist manifest file: %w", err)
+		}
+	}
+	if defaultCRFilePath != "" && (moduleConfig.DefaultCR.IsURL() || vars != nil) {
+		if defaultCRFilePath, err = s.workDirService.Persist(defaultCRFilePath, defaultCRFileName); err != nil {
+			return fmt.Errorf("failed to persist default CR file: %w", err)
+		}
+	}
+
 	resourcePaths := types.NewResourcePaths(defaultCRFilePath, manifestFilePath, opts.TemplateOutput)
+	if resourcePaths.ComponentResources, err = s.resolveComponentResources(moduleConfig, configFilePath); err != nil {
+		return fmt.Errorf("failed to resolve component resources: %w", err)
+	}
+	if err = checkInterrupted(ctx); err != nil {
+		return err
+	}
 
 	if err = s.createComponentConstructor(moduleConfig, resourcePaths, opts); err != nil {
 		return fmt.Errorf("failed to process component: %w", err)
@@ -186,15 +443,254 @@ func (s *Service) Run(opts Options) (rErr error) { //nolint:nonamedreturns // na
 	return nil
 }
 
+// checkInterrupted returns ErrInterrupted if the run has been interrupted or terminated by a signal.
+func checkInterrupted(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ErrInterrupted
+	}
+	return nil
+}
+
+// resolveComponentResources returns the additional resources of the component with the paths of their local files or
+// directories. Local references are relative to the module config file location, remote files are downloaded and
+// copied to the work dir.
+func (s *Service) resolveComponentResources(moduleConfig *contentprovider.ModuleConfig,
+	configFilePath string,
+) ([]types.ComponentResource, error) {
+	resources := make([]types.ComponentResource, 0, len(moduleConfig.ComponentResources))
+	for _, resource := range moduleConfig.ComponentResources {
+		resourcePath := path.Join(configFilePath, resource.Path.String())
+		if resource.Path.IsURL() {
+			filePath, err := s.resourceFileResolver.Resolve(resource.Path, configFilePath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve %s resource: %w", resource.Name, err)
+			}
+			fileName := resource.Name + path.Ext(resource.Path.URL().Path)
+			if resourcePath, err = s.workDirService.Persist(filePath, fileName); err != nil {
+				return nil, fmt.Errorf("failed to persist %s resource: %w", resource.Name, err)
+			}
+		}
+		resources = append(resources, types.ComponentResource{
+			Name:      resource.Name,
+			Type:      resource.Type,
+			MediaType: resource.MediaType,
+			Path:      resourcePath,
+		})
+	}
+	return resources, nil
+}
+
+// versionFromTag returns the version of the version tag pointing at the HEAD commit of the module sources.
+func (s *Service) versionFromTag(opts Options) (string, error) {
+	versions, err := s.getTagVersions(opts)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("HEAD of the module sources is not tagged with a semantic version: %w",
+			ErrVersionTagMismatch)
+	}
+	for _, version := range versions[1:] {
+		if !version.Equal(versions[0]) {
+			return "", fmt.Errorf("HEAD of the module sources is tagged with multiple semantic versions [%s]: %w",
+				joinVersions(versions), ErrVersionTagMismatch)
+		}
+	}
+	return versions[0].String(), nil
+}
+
+// verifyVersionTag checks that the HEAD commit of the module sources is tagged with the module version.
+func (s *Service) verifyVersionTag(moduleConfig *contentprovider.ModuleConfig, opts Options) error {
+	moduleVersion, err := semver.StrictNewVersion(strings.TrimPrefix(moduleConfig.Version, "v"))
+	if err != nil {
+		return fmt.Errorf("module version %s is not a semantic version: %w", moduleConfig.Version,
+			ErrVersionTagMismatch)
+	}
+
+	versions, err := s.getTagVersions(opts)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("HEAD of the module sources is not tagged with module version %s: %w",
+			moduleConfig.Version, ErrVersionTagMismatch)
+	}
+	for _, version := range versions {
+		if version.Equal(moduleVersion) {
+			return nil
+		}
+	}
+	return fmt.Errorf("module version %s does not match the semantic versions [%s] HEAD of the module sources "+
+		"is tagged with: %w", moduleConfig.Version, joinVersions(versions), ErrVersionTagMismatch)
+}
+
+// getTagVersions returns the semantic versions of the tags pointing at the HEAD commit of the module sources. Tags may
+// have a v prefix, tags that are not semantic versions are ignored.
+func (s *Service) getTagVersions(opts Options) ([]*semver.Version, error) {
+	metadata, err := s.gitService.GetMetadata(opts.ModuleSourcesGitDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git tags: %w", err)
+	}
+
+	versions := make([]*semver.Version, 0, len(metadata.Tags))
+	for _, tag := range metadata.Tags {
+		if version, err := semver.StrictNewVersion(strings.TrimPrefix(tag, "v")); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+func joinVersions(versions []*semver.Version) string {
+	names := make([]string, 0, len(versions))
+	for _, version := range versions {
+		names = append(names, version.Original())
+	}
+	return strings.Join(names, ", ")
+}
+
+// resolveRepository infers the repository of the module config from the origin remote of the module sources if it is
+// not configured. A configured repository that differs from the remote is reported as a warning.
+func (s *Service) resolveRepository(moduleConfig *contentprovider.ModuleConfig, opts Options) error {
+	if !opts.usesGitRepository() {
+		if moduleConfig.Repository == "" {
+			return fmt.Errorf("repository must be configured if the module sources are not read from a git "+
+				"repository: %w", commonerrors.ErrInvalidOption)
+		}
+		return nil
+	}
+
+	remoteURL, err := s.gitService.GetRemoteRepositoryURL(opts.ModuleSourcesGitDirectory, originRemoteName)
+	if moduleConfig.Repository == "" {
+		if err != nil {
+			return fmt.Errorf("repository is not configured and can't be inferred from the git remote: %w", err)
+		}
+		moduleConfig.Repository = remoteURL
+		opts.Out.Write(fmt.Sprintf("- Using repository %s of the %s git remote\n", remoteURL, originRemoteName))
+		return nil
+	}
+
+	if err != nil {
+		return nil //nolint:nilerr // the configured repository is used if the remote can't be determined
+	}
+	// Normalize the configured repository to ignore differences such as the case of the host or the .git suffix.
+	configuredURL, err := git.NormalizeRepositoryURL(moduleConfig.Repository)
+	if err == nil && configuredURL != remoteURL {
+		opts.Out.Write(fmt.Sprintf("- WARNING: configured repository %s differs from the repository %s of the %s "+
+			"git remote\n", moduleConfig.Repository, remoteURL, originRemoteName))
+	}
+	return nil
+}
+
+// verifyComponentReferences checks that the versions of the referenced components exist in the reference registry.
+// The verification is skipped if no reference registry is configured.
+func (s *Service) verifyComponentReferences(moduleConfig *contentprovider.ModuleConfig, opts Options) error {
+	if opts.ReferenceRegistry == "" || len(moduleConfig.ComponentReferences) == 0 {
+		return nil
+	}
+
+	opts.Out.Write(fmt.Sprintf("- Verifying component references in %s\n", opts.ReferenceRegistry))
+	for _, reference := range moduleConfig.ComponentReferences {
+		if err := s.componentVerifier.VerifyComponentVersion(opts.ReferenceRegistry, reference.ComponentName,
+			reference.Version); err != nil {
+			return fmt.Errorf("failed to verify component reference %s: %w", reference.Name, err)
+		}
+	}
+	return nil
+}
+
+// verifyWorkingTree checks the module sources git working tree for uncommitted changes, as the recorded commit does not
+// match the packaged sources then. Changes fail release builds unless they are explicitly allowed.
+func (s *Service) verifyWorkingTree(opts Options) error {
+	if !opts.usesGitRepository() {
+		return nil
+	}
+
+	changes, err := s.gitService.GetUncommittedChanges(opts.ModuleSourcesGitDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to get uncommitted changes: %w", err)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("module sources in %s have uncommitted changes in [%s]", opts.ModuleSourcesGitDirectory,
+		strings.Join(changes, ", "))
+	if opts.Release && !opts.AllowDirty {
+		return fmt.Errorf("%s: %w", msg, ErrDirtyWorkingTree)
+	}
+	opts.Out.Write("- WARNING: " + msg + ", the module sources are labeled as dirty\n")
+	return nil
+}
+
+// verifyChecksums verifies the resolved manifest and default CR files against the checksums of the module config. In
+// lock file mode, the checksums of remote files are additionally verified against and recorded in the lock file.
+func (s *Service) verifyChecksums(moduleConfig *contentprovider.ModuleConfig,
+	manifestFilePath, defaultCRFilePath string,
+	opts Options,
+) error {
+	var lockFile *checksum.LockFile
+	if opts.LockFile != "" {
+		var err error
+		if lockFile, err = s.checksumService.LoadLockFile(opts.LockFile); err != nil {
+			return fmt.Errorf("failed to load lock file: %w", err)
+		}
+	}
+
+	lockFileChanged := false
+	verify := func(fileRef contentprovider.UrlOrLocalFile, filePath, expected string) error {
+		remote := fileRef.IsURL() && lockFile != nil
+		if expected == "" && remote {
+			expected = lockFile.Checksums[fileRef.String()]
+		}
+		if expected == "" && !remote {
+			return nil
+		}
+
+		actual, err := s.checksumService.Verify(filePath, fileRef.String(), expected)
+		if err != nil {
+			return err
+		}
+		if remote && lockFile.Checksums[fileRef.String()] != actual {
+			lockFile.Checksums[fileRef.String()] = actual
+			lockFileChanged = true
+		}
+		return nil
+	}
+
+	if err := verify(moduleConfig.Manifest, manifestFilePath, moduleConfig.ManifestSHA256); err != nil {
+		return fmt.Errorf("failed to verify manifest: %w", err)
+	}
+	if defaultCRFilePath != "" {
+		if err := verify(moduleConfig.DefaultCR, defaultCRFilePath, moduleConfig.DefaultCRSHA256); err != nil {
+			return fmt.Errorf("failed to verify default CR: %w", err)
+		}
+	}
+
+	if lockFileChanged {
+		if err := s.checksumService.SaveLockFile(opts.LockFile, lockFile); err != nil {
+			return fmt.Errorf("failed to save lock file: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *Service) createComponentConstructor(moduleConfig *contentprovider.ModuleConfig,
 	resourcePaths *types.ResourcePaths,
 	opts Options,
 ) error {
 	constructor := component.NewConstructor(moduleConfig.Name, moduleConfig.Version)
 
-	if err := s.gitSourcesService.AddGitSourcesToConstructor(constructor, opts.ModuleSourcesGitDirectory,
-		moduleConfig.Repository); err != nil {
-		return fmt.Errorf("failed to add git sources to constructor: %w", err)
+	switch {
+	case opts.NoGitSource:
+		// the module is built without git source
+	case opts.SourceCommit != "":
+		s.gitSourcesService.AddGitSourceWithCommit(constructor, moduleConfig.Repository, opts.SourceCommit)
+	default:
+		if err := s.gitSourcesService.AddGitSourcesToConstructor(constructor, opts.ModuleSourcesGitDirectory,
+			moduleConfig.Repository); err != nil {
+			return fmt.Errorf("failed to add git sources to constructor: %w", err)
+		}
 	}
 
 	images, err := s.extractImagesFromManifest(resourcePaths.RawManifest, opts)
@@ -209,6 +705,10 @@ func (s *Service) createComponentConstructor(moduleConfig *contentprovider.Modul
 		}
 	}
 
+	if err := s.verifyAssociatedResources(moduleConfig, resourcePaths.RawManifest, opts); err != nil {
+		return fmt.Errorf("failed to verify associated resources: %w", err)
+	}
+
 	opts.Out.Write("- Adding oci artifacts to component descriptor\n")
 	if err := s.componentConstructorService.AddImagesToConstructor(constructor, images); err != nil {
 		return fmt.Errorf("failed to add images to component constructor: %w", err)
@@ -225,6 +725,14 @@ func (s *Service) createComponentConstructor(moduleConfig *contentprovider.Modul
 		return fmt.Errorf("failed to add resources to component constructor: %w", err)
 	}
 
+	if len(moduleConfig.ComponentReferences) > 0 {
+		opts.Out.Write("- Adding component references\n")
+		if err = s.componentConstructorService.AddComponentReferences(constructor,
+			toComponentReferences(moduleConfig.ComponentReferences)); err != nil {
+			return fmt.Errorf("failed to add component references to component constructor: %w", err)
+		}
+	}
+
 	opts.Out.Write("- Setting OCM Component labels\n")
 	s.componentConstructorService.SetComponentLabel(constructor,
 		shared.BetaLabel, strconv.FormatBool(moduleConfig.Beta))
@@ -236,7 +744,7 @@ func (s *Service) createComponentConstructor(moduleConfig *contentprovider.Modul
 		common.RequiresDowntimeLabelKey,
 		strconv.FormatBool(moduleConfig.RequiresDowntime))
 
-	isCRDClusterScoped, err := s.crdParserService.IsCRDClusterScoped(resourcePaths)
+	isCRDClusterScoped, err := s.crdParserService.IsCRDClusterScoped(resourcePaths, moduleConfig)
 	if err != nil {
 		return fmt.Errorf("failed to determine if CRD is cluster scoped: %w", err)
 	}
@@ -249,10 +757,24 @@ func (s *Service) createComponentConstructor(moduleConfig *contentprovider.Modul
 	if securityScanEnabled {
 		s.componentConstructorService.SetComponentLabel(constructor,
 			common.SecurityScanLabelKey, common.SecurityScanEnabledValue)
+	}
+	switch {
+	case len(moduleConfig.Responsibles) > 0:
+		s.componentConstructorService.SetResponsibles(constructor, toResponsibleEntries(moduleConfig.Responsibles))
+	case securityScanEnabled:
 		// Add responsibles label with team information
 		s.componentConstructorService.SetResponsiblesLabel(constructor, moduleConfig.Team)
 	}
 
+	if err = s.componentConstructorService.AddLabels(constructor, moduleConfig.ComponentLabels,
+		moduleConfig.ResourceLabels, moduleConfig.SourceLabels); err != nil {
+		return fmt.Errorf("failed to add user-defined labels to component constructor: %w", err)
+	}
+
+	if err = s.makeInputPathsPortable(constructor, opts); err != nil {
+		return err
+	}
+
 	opts.Out.Write("- Creating component constructor file\n")
 	if err = s.componentConstructorService.CreateConstructorFile(constructor,
 		opts.OutputConstructorFile); err != nil {
@@ -261,6 +783,28 @@ func (s *Service) createComponentConstructor(moduleConfig *contentprovider.Modul
 	return nil
 }
 
+// makeInputPathsPortable rewrites the file input paths of the constructor according to the constructor paths mode, so
+// the constructor file can be moved to another location or machine.
+func (s *Service) makeInputPathsPortable(constructor *component.Constructor, opts Options) error {
+	switch opts.ConstructorPaths {
+	case ConstructorPathsBundle:
+		bundleDir := opts.constructorBundleDir()
+		opts.Out.Write(fmt.Sprintf("- Bundling file inputs in %s\n", bundleDir))
+		if err := s.componentConstructorService.BundleFileInputs(constructor, bundleDir); err != nil {
+			return fmt.Errorf("failed to bundle file inputs: %w", err)
+		}
+	case ConstructorPathsRelative:
+	default:
+		return nil
+	}
+
+	if err := s.componentConstructorService.RelativizeInputPaths(constructor,
+		opts.OutputConstructorFile); err != nil {
+		return fmt.Errorf("failed to make input paths relative: %w", err)
+	}
+	return nil
+}
+
 func (s *Service) extractImagesFromManifest(manifestFilePath string, opts Options) ([]string, error) {
 	opts.Out.Write("- Extracting images from raw manifest\n")
 	images, err := s.manifestService.ExtractImagesFromManifest(manifestFilePath)
@@ -270,6 +814,98 @@ func (s *Service) extractImagesFromManifest(manifestFilePath string, opts Option
 	return images, nil
 }
 
+// newVariables returns the variables for expanding the module config, manifest and default CR, or nil if variable
+// expansion is disabled.
+func (s *Service) newVariables(opts Options) (*variables.Variables, error) {
+	if !opts.expandsVariables() {
+		return nil, nil //nolint:nilnil // nil variables disable the expansion
+	}
+
+	values, err := variables.ParseAssignments(opts.Variables)
+	if err != nil {
+		return nil, err
+	}
+	vars := variables.New(values, opts.StrictVariables)
+
+	switch {
+	case opts.NoGitSource:
+		// GIT_COMMIT is not defined without git source
+	case opts.SourceCommit != "":
+		vars.SetDefault(variables.GitCommitVariable, opts.SourceCommit)
+	default:
+		commit, err := s.gitService.GetLatestCommit(opts.ModuleSourcesGitDirectory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest commit: %w", err)
+		}
+		vars.SetDefault(variables.GitCommitVariable, commit)
+	}
+
+	return vars, nil
+}
+
+// verifyAssociatedResources checks that every associated resource is shipped as a CRD in the raw manifest or is a
+// built-in Kubernetes kind. Unknown resources are reported as a warning unless strict mode is enabled.
+func (s *Service) verifyAssociatedResources(moduleConfig *contentprovider.ModuleConfig,
+	manifestFilePath string,
+	opts Options,
+) error {
+	unknown, err := s.associatedResourcesVerifier.VerifyAssociatedResources(moduleConfig, manifestFilePath)
+	if err != nil {
+		return err
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	gvks := make([]string, 0, len(unknown))
+	for _, gvk := range unknown {
+		gvks = append(gvks, gvk.String())
+	}
+	msg := fmt.Sprintf("associated resources [%s] are neither defined by a CRD in the manifest "+
+		"nor built-in Kubernetes kinds", strings.Join(gvks, ", "))
+	if opts.StrictAssociatedResources {
+		return fmt.Errorf("%s: %w", msg, ErrUnknownAssociatedResources)
+	}
+	opts.Out.Write("- WARNING: " + msg + ", they may not be cleaned up on module deletion\n")
+	return nil
+}
+
+func toComponentReferences(references []contentprovider.ComponentReference) []component.ComponentReference {
+	componentReferences := make([]component.ComponentReference, 0, len(references))
+	for _, reference := range references {
+		componentReferences = append(componentReferences, component.ComponentReference{
+			Name:          reference.Name,
+			ComponentName: reference.ComponentName,
+			Version:       reference.Version,
+		})
+	}
+	return componentReferences
+}
+
+// toResponsibleEntries converts the configured responsibles into entries of the responsibles label. GitHub teams and
+// users default to the SAP GitHub hostname.
+func toResponsibleEntries(responsibles []contentprovider.Responsible) []component.ResponsibleEntry {
+	entries := make([]component.ResponsibleEntry, 0, len(responsibles))
+	for _, responsible := range responsibles {
+		hostname := responsible.GitHubHostname
+		if hostname == "" {
+			hostname = common.GitHubHostname
+		}
+
+		entry := component.ResponsibleEntry{Type: responsible.Type}
+		switch responsible.Type {
+		case common.ResponsibleTypeGitHubTeam:
+			entry.TeamName, entry.GitHubHostname = responsible.Name, hostname
+		case common.ResponsibleTypeGitHubUser:
+			entry.Username, entry.GitHubHostname = responsible.Name, hostname
+		case common.ResponsibleTypeEmail:
+			entry.Email = responsible.Name
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
 // getSecurityScanEnabled returns true if securityScanEnabled is nil or true, false if explicitly set to false.
 func getSecurityScanEnabled(moduleConfig *contentprovider.ModuleConfig) bool {
 	if moduleConfig.SecurityScanEnabled == nil {
@@ -278,6 +914,12 @@ func getSecurityScanEnabled(moduleConfig *contentprovider.ModuleConfig) bool {
 	return *moduleConfig.SecurityScanEnabled
 }
 
+func (s *Service) cleanupModuleConfigTempFiles(opts Options) {
+	if err := s.moduleConfigService.CleanupTempFiles(); err != nil {
+		opts.Out.Write(fmt.Sprintf("failed to cleanup temporary module config files: %v\n", err))
+	}
+}
+
 func (s *Service) cleanupTempFiles(opts Options) {
 	if err := s.defaultCRFileResolver.CleanupTempFiles(); err != nil {
 		opts.Out.Write(fmt.Sprintf("failed to cleanup temporary default CR files: %v\n", err))
@@ -285,19 +927,26 @@ func (s *Service) cleanupTempFiles(opts Options) {
 	if err := s.manifestFileResolver.CleanupTempFiles(); err != nil {
 		opts.Out.Write(fmt.Sprintf("failed to cleanup temporary manifest files: %v\n", err))
 	}
+	if err := s.resourceFileResolver.CleanupTempFiles(); err != nil {
+		opts.Out.Write(fmt.Sprintf("failed to cleanup temporary resource files: %v\n", err))
+	}
 }
 
 func (s *Service) createModuleTemplate(
 	moduleConfig *contentprovider.ModuleConfig,
 	resourcePaths *types.ResourcePaths,
 ) error {
-	isCRDClusterScoped, err := s.crdParserService.IsCRDClusterScoped(resourcePaths)
+	isCRDClusterScoped, err := s.crdParserService.IsCRDClusterScoped(resourcePaths, moduleConfig)
 	if err != nil {
 		return fmt.Errorf("failed to determine if CRD is cluster scoped: %w", err)
 	}
 
 	var crData []byte
 	if resourcePaths.DefaultCR != "" {
+		if err = s.crdParserService.ValidateDefaultCRs(resourcePaths); err != nil {
+			return fmt.Errorf("failed to validate default CR: %w", err)
+		}
+
 		crData, err = s.fileSystem.ReadFile(resourcePaths.DefaultCR)
 		if err != nil {
 			return fmt.Errorf("failed to get default CR data: %w", err)
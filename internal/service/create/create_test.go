@@ -1,18 +1,31 @@
 package create_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
+	"github.com/kyma-project/modulectl/internal/service/checksum"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 	"github.com/kyma-project/modulectl/internal/service/create"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/oci"
+	"github.com/kyma-project/modulectl/internal/service/variables"
+	"github.com/kyma-project/modulectl/tools/download"
 	iotools "github.com/kyma-project/modulectl/tools/io"
 )
 
@@ -20,8 +33,10 @@ func Test_NewService_ReturnsError_WhenModuleConfigServiceIsNil(t *testing.T) {
 	_, err := create.NewService(nil, &gitSourcesServiceStub{},
 		&componentConstructorServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
-		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
-		&fileExistsStub{})
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
 
 	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
 	require.Contains(t, err.Error(), "moduleConfigService")
@@ -64,9 +79,11 @@ func Test_CreateModule_ReturnsError_WhenParseAndValidateModuleConfigReturnsError
 	svc, err := create.NewService(&moduleConfigServiceParseErrorStub{}, &gitSourcesServiceStub{},
 		&componentConstructorServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
-		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
-		&fileResolverStub{},
-		&fileExistsStub{})
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -81,9 +98,11 @@ func Test_CreateModule_ReturnsError_WhenResolvingManifestFilePathReturnsError(t
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
 		&componentConstructorServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
-		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverErrorStub{},
-		&fileResolverStub{},
-		&fileExistsStub{})
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverErrorStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -98,9 +117,11 @@ func Test_CreateModule_ReturnsError_WhenResolvingDefaultCRFilePathReturnsError(t
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
 		&componentConstructorServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
-		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
-		&fileResolverErrorStub{},
-		&fileExistsStub{})
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{},
+		&fileResolverErrorStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -125,13 +146,15 @@ func Test_CreateModule_ReturnsError_WhenModuleSourcesGitDirectoryIsEmpty(t *test
 func Test_CreateModule_ReturnsError_WhenModuleSourcesIsNotGitDirectory(t *testing.T) {
 	svc := newTestService(t)
 
-	opts := newCreateOptionsBuilder().withModuleSourcesGitDirectory(".").build()
+	dir := t.TempDir()
+	opts := newCreateOptionsBuilder().withModuleSourcesGitDirectory(dir).build()
 
 	err := svc.Run(opts)
 
 	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
 	require.Contains(t, err.Error(),
-		"currently configured module-sources-git-directory \".\" must point to a valid git repository")
+		fmt.Sprintf("currently configured module-sources-git-directory \"%s\" must point to a valid git repository",
+			dir))
 }
 
 func Test_CreateModule_ReturnsError_WhenVersionCheckFails(t *testing.T) {
@@ -142,9 +165,11 @@ func Test_CreateModule_ReturnsError_WhenVersionCheckFails(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
 		&componentConstructorServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
-		&imageVersionVerifierErrorStub{expectedErrMsg}, &manifestServiceStub{},
-		manifestResolverStub, defaultCRResolverStub,
-		&fileExistsStub{})
+		&imageVersionVerifierErrorStub{expectedErrMsg}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{},
+		manifestResolverStub, defaultCRResolverStub, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -161,9 +186,11 @@ func Test_CreateModule_DoesNotCleanUpTempFiles_OnSuccess(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
 		&componentConstructorServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
-		&imageVersionVerifierStub{}, &manifestServiceStub{},
-		manifestResolverStub, defaultCRResolverStub,
-		&fileExistsStub{})
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{},
+		manifestResolverStub, defaultCRResolverStub, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -183,9 +210,11 @@ func Test_CreateModule_CleansUpTempFiles_OnError(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceErrorStub{},
 		&componentConstructorServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
-		&imageVersionVerifierStub{}, &manifestServiceStub{},
-		manifestResolverStub, defaultCRResolverStub,
-		&fileExistsStub{})
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{},
+		manifestResolverStub, defaultCRResolverStub, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -199,15 +228,871 @@ func Test_CreateModule_CleansUpTempFiles_OnError(t *testing.T) {
 		"expected default CR resolver to clean up temporary files on error")
 }
 
+func Test_CreateModule_WarnsAboutUnknownAssociatedResources(t *testing.T) {
+	verifierStub := &associatedResourcesVerifierStub{
+		unknown: []*metav1.GroupVersionKind{{Group: "networking.istio.io", Version: "v1", Kind: "Gateway"}},
+	}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, verifierStub,
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	out := &strings.Builder{}
+	opts := newCreateOptionsBuilder().withOut(iotools.NewDefaultOut(out)).build()
+
+	err = svc.Run(opts)
+
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "WARNING: associated resources [networking.istio.io/v1, Kind=Gateway]")
+}
+
+func Test_CreateModule_ReturnsError_WhenUnknownAssociatedResourcesInStrictMode(t *testing.T) {
+	verifierStub := &associatedResourcesVerifierStub{
+		unknown: []*metav1.GroupVersionKind{{Group: "networking.istio.io", Version: "v1", Kind: "Gateway"}},
+	}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, verifierStub,
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	opts := newCreateOptionsBuilder().build()
+	opts.StrictAssociatedResources = true
+
+	err = svc.Run(opts)
+
+	require.ErrorIs(t, err, create.ErrUnknownAssociatedResources)
+}
+
+func Test_CreateModule_DoesNotExpandVariables_ByDefault(t *testing.T) {
+	variableStub := &variableServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, variableStub, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.NoError(t, err)
+	assert.Empty(t, variableStub.expandedFiles)
+}
+
+func Test_CreateModule_ExpandsVariables_WhenVariablesAreSet(t *testing.T) {
+	variableStub := &variableServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, variableStub, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	opts := newCreateOptionsBuilder().build()
+	opts.Variables = []string{"REGISTRY=europe-docker.pkg.dev"}
+
+	err = svc.Run(opts)
+
+	require.NoError(t, err)
+	assert.Len(t, variableStub.expandedFiles, 2, "expected manifest and default CR to be expanded")
+	require.NotNil(t, variableStub.vars)
+	for name, expected := range map[string]string{
+		"REGISTRY":                      "europe-docker.pkg.dev",
+		variables.GitCommitVariable:     "abcdef",
+		variables.ModuleVersionVariable: "1.43.1",
+		variables.ModuleNameVariable:    "kyma-project.io/module/telemetry",
+	} {
+		value, ok := variableStub.vars.Lookup(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, expected, value, name)
+	}
+}
+
+func Test_CreateModule_PrintsEffectiveConfig_WithoutResolvingFiles(t *testing.T) {
+	manifestResolver := &fileResolverErrorStub{}
+	moduleConfigStub := &moduleConfigServiceStub{}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, manifestResolver, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	out := &strings.Builder{}
+	opts := newCreateOptionsBuilder().withOut(iotools.NewDefaultOut(out)).build()
+	opts.PrintEffectiveConfig = true
+
+	err = svc.Run(opts)
+
+	require.NoError(t, err)
+	require.Equal(t, "name: kyma-project.io/module/telemetry\n", out.String())
+	require.Equal(t, 1, moduleConfigStub.cleanupTempFilesCallCount)
+}
+
+func Test_CreateModule_CleansUpModuleConfigTempFiles_BeforeFinishingWorkDir(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{}
+	cleanupCallCountOnFinish := -1
+	workDirStub := &workDirServiceStub{onFinish: func() {
+		cleanupCallCountOnFinish = moduleConfigStub.cleanupTempFilesCallCount
+	}}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, workDirStub, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, cleanupCallCountOnFinish)
+	require.Equal(t, 1, moduleConfigStub.cleanupTempFilesCallCount)
+}
+
+func Test_CreateModule_ReturnsError_WhenChecksumDoesNotMatch(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:           "kyma-project.io/module/telemetry",
+		Version:        "1.43.1",
+		Manifest:       contentprovider.MustUrlOrLocalFile("https://example.com/manifest.yaml"),
+		ManifestSHA256: "expected",
+	}}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{},
+		&checksumServiceStub{checksums: map[string]string{"/tmp/some-file.yaml": "actual"}},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.ErrorIs(t, err, checksum.ErrChecksumMismatch)
+	require.ErrorContains(t, err, "failed to verify manifest")
+}
+
+func Test_CreateModule_RecordsChecksumsOfRemoteFiles_InLockFileMode(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:      "kyma-project.io/module/telemetry",
+		Version:   "1.43.1",
+		Manifest:  contentprovider.MustUrlOrLocalFile("https://example.com/manifest.yaml"),
+		DefaultCR: contentprovider.MustUrlOrLocalFile("default-cr.yaml"),
+	}}
+	checksumStub := &checksumServiceStub{checksums: map[string]string{"/tmp/some-file.yaml": "actual"}}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, checksumStub,
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	opts := newCreateOptionsBuilder().build()
+	opts.LockFile = "module-config.lock"
+
+	err = svc.Run(opts)
+
+	require.NoError(t, err)
+	require.NotNil(t, checksumStub.saved)
+	require.Equal(t, map[string]string{"https://example.com/manifest.yaml": "actual"}, checksumStub.saved.Checksums)
+}
+
+func Test_CreateModule_ReturnsError_WhenLockFileChecksumDoesNotMatch(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:     "kyma-project.io/module/telemetry",
+		Version:  "1.43.1",
+		Manifest: contentprovider.MustUrlOrLocalFile("https://example.com/manifest.yaml"),
+	}}
+	checksumStub := &checksumServiceStub{
+		checksums: map[string]string{"/tmp/some-file.yaml": "actual"},
+		lockFile: &checksum.LockFile{
+			Checksums: map[string]string{"https://example.com/manifest.yaml": "recorded"},
+		},
+	}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, checksumStub,
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	opts := newCreateOptionsBuilder().build()
+	opts.LockFile = "module-config.lock"
+
+	err = svc.Run(opts)
+
+	require.ErrorIs(t, err, checksum.ErrChecksumMismatch)
+	require.Nil(t, checksumStub.saved)
+}
+
+func Test_CreateModule_ConfiguresDownloads(t *testing.T) {
+	downloadStub := &downloadServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		downloadStub, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	opts := newCreateOptionsBuilder().build()
+	opts.Offline = true
+	opts.DownloadTimeout = time.Minute
+	opts.DownloadRetries = 5
+
+	err = svc.Run(opts)
+
+	require.NoError(t, err)
+	require.True(t, downloadStub.settings.Offline)
+	require.Equal(t, time.Minute, downloadStub.settings.Timeout)
+	require.Equal(t, 5, downloadStub.settings.Retries)
+	require.NotNil(t, downloadStub.settings.Context)
+}
+
+func Test_CreateModule_FinishesWorkDir(t *testing.T) {
+	tests := []struct {
+		name              string
+		gitSourcesService create.GitSourcesService
+		expectedSuccess   bool
+	}{
+		{name: "on success", gitSourcesService: &gitSourcesServiceStub{}, expectedSuccess: true},
+		{name: "on error", gitSourcesService: &gitSourcesServiceErrorStub{}, expectedSuccess: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			workDirStub := &workDirServiceStub{}
+			svc, err := create.NewService(&moduleConfigServiceStub{}, test.gitSourcesService,
+				&componentConstructorServiceStub{},
+				&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+				&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+				&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+				&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+				&downloadServiceStub{}, workDirStub, &componentVerifierStub{})
+			require.NoError(t, err)
+
+			opts := newCreateOptionsBuilder().build()
+			opts.WorkDir = "work"
+			opts.KeepWorkDir = true
+
+			_ = svc.Run(opts)
+
+			assert.Equal(t, "work", workDirStub.dir)
+			assert.True(t, workDirStub.keep)
+			require.NotNil(t, workDirStub.success, "expected work dir to be finished")
+			assert.Equal(t, test.expectedSuccess, *workDirStub.success)
+		})
+	}
+}
+
+func Test_CreateModule_CleansUpWorkDir_WhenInterrupted(t *testing.T) {
+	workDirStub := &workDirServiceStub{}
+	constructorStub := &componentConstructorServiceStub{}
+	downloadStub := &downloadServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceInterruptStub{}, &gitSourcesServiceStub{},
+		constructorStub,
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		downloadStub, workDirStub, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.ErrorIs(t, err, create.ErrInterrupted)
+	require.NotNil(t, workDirStub.success, "expected work dir to be finished")
+	assert.False(t, *workDirStub.success)
+	require.ErrorIs(t, downloadStub.settings.Context.Err(), context.Canceled,
+		"expected downloads of the interrupted run to be canceled")
+}
+
+func Test_CreateModule_PersistsRemoteManifest_InWorkDir(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:      "kyma-project.io/module/telemetry",
+		Version:   "1.43.1",
+		Manifest:  contentprovider.MustUrlOrLocalFile("https://example.com/manifest.yaml"),
+		DefaultCR: contentprovider.MustUrlOrLocalFile("default-cr.yaml"),
+	}}
+	workDirStub := &workDirServiceStub{}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, workDirStub, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"raw-manifest.yaml"}, workDirStub.persisted,
+		"expected only the remote manifest to be persisted")
+}
+
+func Test_CreateModule_MakesInputPathsPortable(t *testing.T) {
+	tests := []struct {
+		name                    string
+		constructorPaths        string
+		expectedBundleDir       string
+		expectedConstructorFile string
+	}{
+		{name: "absolute", constructorPaths: create.ConstructorPathsAbsolute},
+		{
+			name:                    "relative",
+			constructorPaths:        create.ConstructorPathsRelative,
+			expectedConstructorFile: "out/constructor.yaml",
+		},
+		{
+			name:                    "bundle",
+			constructorPaths:        create.ConstructorPathsBundle,
+			expectedBundleDir:       "out/constructor-bundle",
+			expectedConstructorFile: "out/constructor.yaml",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			constructorStub := &componentConstructorServiceStub{}
+			svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+				constructorStub,
+				&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+				&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+				&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+				&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+				&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+			require.NoError(t, err)
+
+			opts := newCreateOptionsBuilder().build()
+			opts.OutputConstructorFile = "out/constructor.yaml"
+			opts.ConstructorPaths = test.constructorPaths
+
+			err = svc.Run(opts)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBundleDir, constructorStub.bundleDir)
+			assert.Equal(t, test.expectedConstructorFile, constructorStub.constructorFile)
+		})
+	}
+}
+
+func Test_CreateModule_VerifiesWorkingTree(t *testing.T) {
+	tests := []struct {
+		name        string
+		release     bool
+		allowDirty  bool
+		expectedErr bool
+	}{
+		{name: "warns without release mode"},
+		{name: "fails in release mode", release: true, expectedErr: true},
+		{name: "warns in release mode when dirty is allowed", release: true, allowDirty: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+				&componentConstructorServiceStub{},
+				&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+				&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+				&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+				&fileExistsStub{}, &gitServiceStub{changes: []string{"config/manifest.yaml"}},
+				&variableServiceStub{}, &checksumServiceStub{},
+				&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+			require.NoError(t, err)
+
+			out := &strings.Builder{}
+			opts := newCreateOptionsBuilder().withOut(iotools.NewDefaultOut(out)).build()
+			opts.Release = test.release
+			opts.AllowDirty = test.allowDirty
+
+			err = svc.Run(opts)
+
+			if test.expectedErr {
+				require.ErrorIs(t, err, create.ErrDirtyWorkingTree)
+				require.ErrorContains(t, err, "uncommitted changes in [config/manifest.yaml]")
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), "WARNING: module sources in ../../../ have uncommitted changes")
+		})
+	}
+}
+
+func Test_CreateModule_ResolvesRepository(t *testing.T) {
+	tests := []struct {
+		name               string
+		repository         string
+		remoteURL          string
+		remoteErr          error
+		expectedRepository string
+		expectedOutput     string
+		expectedErr        string
+	}{
+		{
+			name:               "inferred from the remote",
+			remoteURL:          "https://github.com/kyma-project/telemetry-manager",
+			expectedRepository: "https://github.com/kyma-project/telemetry-manager",
+			expectedOutput:     "Using repository https://github.com/kyma-project/telemetry-manager of the origin",
+		},
+		{
+			name:               "configured and matching the remote",
+			repository:         "https://GitHub.com/kyma-project/telemetry-manager.git",
+			remoteURL:          "https://github.com/kyma-project/telemetry-manager",
+			expectedRepository: "https://GitHub.com/kyma-project/telemetry-manager.git",
+		},
+		{
+			name:               "configured and differing from the remote",
+			repository:         "https://github.com/kyma-project/telemetry-manager",
+			remoteURL:          "https://github.com/fork/telemetry-manager",
+			expectedRepository: "https://github.com/kyma-project/telemetry-manager",
+			expectedOutput: "WARNING: configured repository https://github.com/kyma-project/telemetry-manager " +
+				"differs from the repository https://github.com/fork/telemetry-manager of the origin git remote",
+		},
+		{
+			name:               "configured without remote",
+			repository:         "https://github.com/kyma-project/telemetry-manager",
+			remoteErr:          errors.New("remote not found"),
+			expectedRepository: "https://github.com/kyma-project/telemetry-manager",
+		},
+		{
+			name:        "neither configured nor remote",
+			remoteErr:   errors.New("remote not found"),
+			expectedErr: "repository is not configured and can't be inferred from the git remote",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			moduleConfig := &contentprovider.ModuleConfig{
+				Name:       "kyma-project.io/module/telemetry",
+				Version:    "1.43.1",
+				Repository: test.repository,
+			}
+			svc, err := create.NewService(&moduleConfigServiceStub{moduleConfig: moduleConfig},
+				&gitSourcesServiceStub{},
+				&componentConstructorServiceStub{},
+				&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+				&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+				&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+				&fileExistsStub{}, &gitServiceStub{remoteURL: test.remoteURL, remoteErr: test.remoteErr},
+				&variableServiceStub{}, &checksumServiceStub{},
+				&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+			require.NoError(t, err)
+
+			out := &strings.Builder{}
+			opts := newCreateOptionsBuilder().withOut(iotools.NewDefaultOut(out)).build()
+
+			err = svc.Run(opts)
+
+			if test.expectedErr != "" {
+				require.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRepository, moduleConfig.Repository)
+			if test.expectedOutput != "" {
+				assert.Contains(t, out.String(), test.expectedOutput)
+			} else {
+				assert.NotContains(t, out.String(), "repository")
+			}
+		})
+	}
+}
+
+func Test_CreateModule_VerifiesVersionTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []string
+		expectedErr string
+	}{
+		{name: "tag without prefix", tags: []string{"1.43.1"}},
+		{name: "tag with v prefix", tags: []string{"latest", "v1.43.1"}},
+		{
+			name:        "no tag",
+			expectedErr: "HEAD of the module sources is not tagged with module version 1.43.1",
+		},
+		{
+			name:        "other version tag",
+			tags:        []string{"v1.43.0"},
+			expectedErr: "module version 1.43.1 does not match the semantic versions [1.43.0]",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+				&componentConstructorServiceStub{},
+				&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+				&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+				&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+				&fileExistsStub{}, &gitServiceStub{tags: test.tags},
+				&variableServiceStub{}, &checksumServiceStub{},
+				&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+			require.NoError(t, err)
+
+			opts := newCreateOptionsBuilder().build()
+			opts.RequireVersionTag = true
+
+			err = svc.Run(opts)
+
+			if test.expectedErr != "" {
+				require.ErrorIs(t, err, create.ErrVersionTagMismatch)
+				require.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_CreateModule_DerivesVersionFromTag(t *testing.T) {
+	tests := []struct {
+		name            string
+		tags            []string
+		expectedVersion string
+		expectedErr     string
+	}{
+		{name: "single tag", tags: []string{"latest", "v2.0.0"}, expectedVersion: "2.0.0"},
+		{name: "tags of the same version", tags: []string{"2.0.0", "v2.0.0"}, expectedVersion: "2.0.0"},
+		{
+			name:        "no version tag",
+			tags:        []string{"latest"},
+			expectedErr: "HEAD of the module sources is not tagged with a semantic version",
+		},
+		{
+			name:        "multiple versions",
+			tags:        []string{"v2.0.0", "v2.1.0-rc.1"},
+			expectedErr: "HEAD of the module sources is tagged with multiple semantic versions [2.0.0, 2.1.0-rc.1]",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			moduleConfigStub := &moduleConfigServiceStub{}
+			svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+				&componentConstructorServiceStub{},
+				&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+				&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+				&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+				&fileExistsStub{}, &gitServiceStub{tags: test.tags},
+				&variableServiceStub{}, &checksumServiceStub{},
+				&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+			require.NoError(t, err)
+
+			opts := newCreateOptionsBuilder().build()
+			opts.VersionFromTag = true
+			opts.RequireVersionTag = true
+
+			err = svc.Run(opts)
+
+			if test.expectedErr != "" {
+				require.ErrorIs(t, err, create.ErrVersionTagMismatch)
+				require.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedVersion, moduleConfigStub.opts.Version)
+		})
+	}
+}
+
+func Test_CreateModule_AddsGitSource_DependingOnOptions(t *testing.T) {
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		name                 string
+		noGitSource          bool
+		sourceCommit         string
+		expectedGitRepoPath  string
+		expectedSourceCommit string
+		expectedGitCommitVar string
+	}{
+		{name: "from git repository", expectedGitRepoPath: "../../../", expectedGitCommitVar: "abcdef"},
+		{name: "with source commit", sourceCommit: commit, expectedSourceCommit: commit, expectedGitCommitVar: commit},
+		{name: "without git source", noGitSource: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+				Name:       "kyma-project.io/module/telemetry",
+				Version:    "1.43.1",
+				Repository: "https://github.com/kyma-project/telemetry-manager",
+			}}
+			gitSourcesStub := &gitSourcesServiceStub{}
+			variableStub := &variableServiceStub{}
+			svc, err := create.NewService(moduleConfigStub, gitSourcesStub,
+				&componentConstructorServiceStub{},
+				&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+				&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+				&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+				&fileExistsStub{}, &gitServiceStub{changes: []string{"manifest.yaml"}}, variableStub,
+				&checksumServiceStub{}, &downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+			require.NoError(t, err)
+
+			opts := newCreateOptionsBuilder().build()
+			opts.ExpandVariables = true
+			opts.Release = true
+			opts.NoGitSource = test.noGitSource
+			opts.SourceCommit = test.sourceCommit
+			if !test.noGitSource && test.sourceCommit == "" {
+				opts.AllowDirty = true
+			}
+
+			err = svc.Run(opts)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedGitRepoPath, gitSourcesStub.gitRepoPath)
+			assert.Equal(t, test.expectedSourceCommit, gitSourcesStub.sourceCommit)
+			require.NotNil(t, variableStub.vars)
+			gitCommit, _ := variableStub.vars.Lookup(variables.GitCommitVariable)
+			assert.Equal(t, test.expectedGitCommitVar, gitCommit)
+		})
+	}
+}
+
+func Test_CreateModule_ReturnsError_WhenRepositoryIsNotConfigured_WithoutGitRepository(t *testing.T) {
+	svc := newTestService(t)
+
+	opts := newCreateOptionsBuilder().build()
+	opts.NoGitSource = true
+
+	err := svc.Run(opts)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.ErrorContains(t, err, "repository must be configured if the module sources are not read from a git "+
+		"repository")
+}
+
+func Test_CreateModule_AddsUserDefinedLabels(t *testing.T) {
+	componentLabels := []component.Label{{Name: "example.com/owner", Value: map[string]any{"team": "jellyfish"}}}
+	resourceLabels := map[string][]component.Label{"raw-manifest": {{Name: "example.com/purpose", Value: "deploy"}}}
+	sourceLabels := map[string][]component.Label{"module-sources": {{Name: "example.com/reviewed", Value: true}}}
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:            "kyma-project.io/module/telemetry",
+		Version:         "1.43.1",
+		ComponentLabels: componentLabels,
+		ResourceLabels:  resourceLabels,
+		SourceLabels:    sourceLabels,
+	}}
+	componentConstructorStub := &componentConstructorServiceStub{}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		componentConstructorStub,
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.NoError(t, err)
+	assert.Equal(t, componentLabels, componentConstructorStub.componentLabels)
+	assert.Equal(t, resourceLabels, componentConstructorStub.resourceLabels)
+	assert.Equal(t, sourceLabels, componentConstructorStub.sourceLabels)
+}
+
+func Test_CreateModule_AddsComponentResources(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/telemetry",
+		Version: "1.43.1",
+		ComponentResources: []contentprovider.ComponentResource{
+			{Name: "docs", Type: "directoryTree", Path: contentprovider.MustUrlOrLocalFile("docs")},
+			{
+				Name:      "dashboard",
+				Type:      "grafanaDashboard",
+				MediaType: "application/json",
+				Path:      contentprovider.MustUrlOrLocalFile("https://example.com/dashboards/telemetry.json"),
+			},
+		},
+	}}
+	componentConstructorStub := &componentConstructorServiceStub{}
+	workDirStub := &workDirServiceStub{}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		componentConstructorStub,
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, workDirStub, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	opts := newCreateOptionsBuilder().withModuleConfigFile("module/module-config.yaml").build()
+
+	err = svc.Run(opts)
+
+	require.NoError(t, err)
+	require.NotNil(t, componentConstructorStub.resourcePaths)
+	assert.Equal(t, []types.ComponentResource{
+		{Name: "docs", Type: "directoryTree", Path: "module/docs"},
+		{Name: "dashboard", Type: "grafanaDashboard", MediaType: "application/json", Path: "dashboard.json"},
+	}, componentConstructorStub.resourcePaths.ComponentResources)
+	assert.Contains(t, workDirStub.persisted, "dashboard.json")
+}
+
+func Test_CreateModule_AddsAndVerifiesComponentReferences(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/telemetry",
+		Version: "1.43.1",
+		ComponentReferences: []contentprovider.ComponentReference{
+			{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+		},
+	}}
+	componentConstructorStub := &componentConstructorServiceStub{}
+	componentVerifier := &componentVerifierStub{}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		componentConstructorStub,
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, componentVerifier)
+	require.NoError(t, err)
+
+	opts := newCreateOptionsBuilder().build()
+	opts.ReferenceRegistry = "europe-docker.pkg.dev/kyma-project/prod"
+
+	err = svc.Run(opts)
+
+	require.NoError(t, err)
+	assert.Equal(t, []component.ComponentReference{
+		{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+	}, componentConstructorStub.references)
+	assert.Equal(t, []string{"europe-docker.pkg.dev/kyma-project/prod/kyma-project.io/module/istio:1.2.0"},
+		componentVerifier.verified)
+}
+
+func Test_CreateModule_DoesNotVerifyComponentReferences_WhenReferenceRegistryIsNotSet(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/telemetry",
+		Version: "1.43.1",
+		ComponentReferences: []contentprovider.ComponentReference{
+			{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+		},
+	}}
+	componentVerifier := &componentVerifierStub{err: oci.ErrComponentVersionNotFound}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, componentVerifier)
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.NoError(t, err)
+	assert.Empty(t, componentVerifier.verified)
+}
+
+func Test_CreateModule_ReturnsError_WhenReferencedComponentVersionDoesNotExist(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/telemetry",
+		Version: "1.43.1",
+		ComponentReferences: []contentprovider.ComponentReference{
+			{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+		},
+	}}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		&componentConstructorServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{},
+		&componentVerifierStub{err: oci.ErrComponentVersionNotFound})
+	require.NoError(t, err)
+
+	opts := newCreateOptionsBuilder().build()
+	opts.ReferenceRegistry = "europe-docker.pkg.dev/kyma-project/prod"
+
+	err = svc.Run(opts)
+
+	require.ErrorIs(t, err, oci.ErrComponentVersionNotFound)
+	require.ErrorContains(t, err, "failed to verify component reference istio")
+}
+
+func Test_CreateModule_SetsConfiguredResponsibles_InsteadOfTeam(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/telemetry",
+		Version: "1.43.1",
+		Team:    "kyma/huskies",
+		Responsibles: []contentprovider.Responsible{
+			{Type: "githubTeam", Name: "kyma-project/huskies", GitHubHostname: "github.com"},
+			{Type: "githubUser", Name: "octocat"},
+			{Type: "emailAddress", Name: "huskies@example.com"},
+		},
+	}}
+	componentConstructorStub := &componentConstructorServiceStub{}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		componentConstructorStub,
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.NoError(t, err)
+	assert.Empty(t, componentConstructorStub.team)
+	assert.Equal(t, []component.ResponsibleEntry{
+		{GitHubHostname: "github.com", TeamName: "kyma-project/huskies", Type: "githubTeam"},
+		{GitHubHostname: "github.tools.sap", Username: "octocat", Type: "githubUser"},
+		{Email: "huskies@example.com", Type: "emailAddress"},
+	}, componentConstructorStub.responsibles)
+}
+
+func Test_CreateModule_SetsTeamAsResponsible_WhenNoResponsiblesAreConfigured(t *testing.T) {
+	moduleConfigStub := &moduleConfigServiceStub{moduleConfig: &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/telemetry",
+		Version: "1.43.1",
+		Team:    "kyma/huskies",
+	}}
+	componentConstructorStub := &componentConstructorServiceStub{}
+	svc, err := create.NewService(moduleConfigStub, &gitSourcesServiceStub{},
+		componentConstructorStub,
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.NoError(t, err)
+	assert.Equal(t, "kyma/huskies", componentConstructorStub.team)
+	assert.Nil(t, componentConstructorStub.responsibles)
+}
+
 // newTestService creates a service with all default stubs for convenience.
 func newTestService(t *testing.T) *create.Service {
 	t.Helper()
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
 		&componentConstructorServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
-		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
-		&fileResolverStub{},
-		&fileExistsStub{})
+		&imageVersionVerifierStub{}, &associatedResourcesVerifierStub{},
+		&manifestServiceStub{}, &fileResolverStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&fileExistsStub{}, &gitServiceStub{}, &variableServiceStub{}, &checksumServiceStub{},
+		&downloadServiceStub{}, &workDirServiceStub{}, &componentVerifierStub{})
 	require.NoError(t, err)
 	return svc
 }
@@ -291,9 +1176,19 @@ func (*fileResolverErrorStub) CleanupTempFiles() []error {
 	return []error{errors.New("failed to cleanup temp files")}
 }
 
-type moduleConfigServiceStub struct{}
+type moduleConfigServiceStub struct {
+	moduleConfig              *contentprovider.ModuleConfig
+	opts                      moduleconfigreader.Options
+	cleanupTempFilesCallCount int
+}
 
-func (*moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string) (*contentprovider.ModuleConfig, error) {
+func (s *moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string,
+	opts moduleconfigreader.Options,
+) (*contentprovider.ModuleConfig, error) {
+	s.opts = opts
+	if s.moduleConfig != nil {
+		return s.moduleConfig, nil
+	}
 	var fileRef contentprovider.UrlOrLocalFile
 	if err := fileRef.FromString("default-cr.yaml"); err != nil {
 		return nil, err
@@ -305,22 +1200,70 @@ func (*moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string) (*content
 	}, nil
 }
 
+func (*moduleConfigServiceStub) GetEffectiveModuleConfig(_ string, _ moduleconfigreader.Options) (string, error) {
+	return "name: kyma-project.io/module/telemetry\n", nil
+}
+
+func (s *moduleConfigServiceStub) CleanupTempFiles() []error {
+	s.cleanupTempFilesCallCount++
+	return nil
+}
+
+// moduleConfigServiceInterruptStub interrupts the process while the module config is parsed.
+type moduleConfigServiceInterruptStub struct {
+	moduleConfigServiceStub
+}
+
+func (s *moduleConfigServiceInterruptStub) ParseAndValidateModuleConfig(moduleConfigFile string,
+	opts moduleconfigreader.Options,
+) (*contentprovider.ModuleConfig, error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGINT); err != nil {
+		return nil, err
+	}
+	<-signals
+	// Give the signal handler of the run time to cancel its context.
+	time.Sleep(100 * time.Millisecond)
+	return s.moduleConfigServiceStub.ParseAndValidateModuleConfig(moduleConfigFile, opts)
+}
+
 type moduleConfigServiceParseErrorStub struct{}
 
+func (*moduleConfigServiceParseErrorStub) GetEffectiveModuleConfig(_ string,
+	_ moduleconfigreader.Options,
+) (string, error) {
+	return "", errors.New("failed to read module config file")
+}
+
+func (*moduleConfigServiceParseErrorStub) CleanupTempFiles() []error {
+	return nil
+}
+
 func (*moduleConfigServiceParseErrorStub) ParseAndValidateModuleConfig(
 	_ string,
+	_ moduleconfigreader.Options,
 ) (*contentprovider.ModuleConfig, error) {
 	return nil, errors.New("failed to read module config file")
 }
 
-type gitSourcesServiceStub struct{}
+type gitSourcesServiceStub struct {
+	gitRepoPath  string
+	sourceCommit string
+}
 
 func (s *gitSourcesServiceStub) AddGitSourcesToConstructor(_ *component.Constructor,
-	_, _ string,
+	gitRepoPath, _ string,
 ) error {
+	s.gitRepoPath = gitRepoPath
 	return nil
 }
 
+func (s *gitSourcesServiceStub) AddGitSourceWithCommit(_ *component.Constructor, _, commit string) {
+	s.sourceCommit = commit
+}
+
 type gitSourcesServiceErrorStub struct{}
 
 func (s *gitSourcesServiceErrorStub) AddGitSourcesToConstructor(_ *component.Constructor,
@@ -329,7 +1272,19 @@ func (s *gitSourcesServiceErrorStub) AddGitSourcesToConstructor(_ *component.Con
 	return errors.New("unexpected error")
 }
 
-type componentConstructorServiceStub struct{}
+func (s *gitSourcesServiceErrorStub) AddGitSourceWithCommit(_ *component.Constructor, _, _ string) {}
+
+type componentConstructorServiceStub struct {
+	bundleDir       string
+	constructorFile string
+	componentLabels []component.Label
+	resourceLabels  map[string][]component.Label
+	sourceLabels    map[string][]component.Label
+	resourcePaths   *types.ResourcePaths
+	references      []component.ComponentReference
+	team            string
+	responsibles    []component.ResponsibleEntry
+}
 
 func (c *componentConstructorServiceStub) AddImagesToConstructor(_ *component.Constructor,
 	_ []string,
@@ -338,8 +1293,9 @@ func (c *componentConstructorServiceStub) AddImagesToConstructor(_ *component.Co
 }
 
 func (c *componentConstructorServiceStub) AddResources(_ *component.Constructor,
-	_ *types.ResourcePaths,
+	resourcePaths *types.ResourcePaths,
 ) error {
+	c.resourcePaths = resourcePaths
 	return nil
 }
 
@@ -349,12 +1305,57 @@ func (c *componentConstructorServiceStub) CreateConstructorFile(_ *component.Con
 	return nil
 }
 
+func (c *componentConstructorServiceStub) BundleFileInputs(_ *component.Constructor, bundleDir string) error {
+	c.bundleDir = bundleDir
+	return nil
+}
+
+func (c *componentConstructorServiceStub) RelativizeInputPaths(_ *component.Constructor,
+	constructorFile string,
+) error {
+	c.constructorFile = constructorFile
+	return nil
+}
+
 func (c *componentConstructorServiceStub) SetComponentLabel(_ *component.Constructor,
 	_, _ string) {
 }
 
 func (c *componentConstructorServiceStub) SetResponsiblesLabel(_ *component.Constructor,
-	_ string) {
+	team string) {
+	c.team = team
+}
+
+func (c *componentConstructorServiceStub) SetResponsibles(_ *component.Constructor,
+	responsibles []component.ResponsibleEntry,
+) {
+	c.responsibles = responsibles
+}
+
+func (c *componentConstructorServiceStub) AddLabels(_ *component.Constructor, componentLabels []component.Label,
+	resourceLabels, sourceLabels map[string][]component.Label,
+) error {
+	c.componentLabels = componentLabels
+	c.resourceLabels = resourceLabels
+	c.sourceLabels = sourceLabels
+	return nil
+}
+
+func (c *componentConstructorServiceStub) AddComponentReferences(_ *component.Constructor,
+	references []component.ComponentReference,
+) error {
+	c.references = references
+	return nil
+}
+
+type componentVerifierStub struct {
+	verified []string
+	err      error
+}
+
+func (v *componentVerifierStub) VerifyComponentVersion(registry, componentName, version string) error {
+	v.verified = append(v.verified, registry+"/"+componentName+":"+version)
+	return v.err
 }
 
 type ModuleTemplateServiceStub struct{}
@@ -367,10 +1368,16 @@ func (*ModuleTemplateServiceStub) GenerateModuleTemplate(_ *contentprovider.Modu
 
 type CRDParserServiceStub struct{}
 
-func (*CRDParserServiceStub) IsCRDClusterScoped(_ *types.ResourcePaths) (bool, error) {
+func (*CRDParserServiceStub) IsCRDClusterScoped(_ *types.ResourcePaths,
+	_ *contentprovider.ModuleConfig,
+) (bool, error) {
 	return false, nil
 }
 
+func (*CRDParserServiceStub) ValidateDefaultCRs(_ *types.ResourcePaths) error {
+	return nil
+}
+
 type imageVersionVerifierStub struct{}
 
 func (*imageVersionVerifierStub) VerifyModuleResources(_ *contentprovider.ModuleConfig,
@@ -392,6 +1399,116 @@ func (ivs *imageVersionVerifierErrorStub) VerifyModuleResources(_ *contentprovid
 	return nil
 }
 
+type gitServiceStub struct {
+	changes   []string
+	remoteURL string
+	remoteErr error
+	tags      []string
+}
+
+func (*gitServiceStub) GetLatestCommit(_ string) (string, error) {
+	return "abcdef", nil
+}
+
+func (s *gitServiceStub) GetUncommittedChanges(_ string) ([]string, error) {
+	return s.changes, nil
+}
+
+func (s *gitServiceStub) GetMetadata(_ string) (*types.GitMetadata, error) {
+	return &types.GitMetadata{Tags: s.tags}, nil
+}
+
+func (s *gitServiceStub) GetRemoteRepositoryURL(_, _ string) (string, error) {
+	if s.remoteErr != nil {
+		return "", s.remoteErr
+	}
+	if s.remoteURL == "" {
+		return "https://github.com/kyma-project/telemetry-manager", nil
+	}
+	return s.remoteURL, nil
+}
+
+type variableServiceStub struct {
+	expandedFiles []string
+	vars          *variables.Variables
+}
+
+func (s *variableServiceStub) ExpandFile(filePath, _ string, vars *variables.Variables) (string, error) {
+	s.expandedFiles = append(s.expandedFiles, filePath)
+	s.vars = vars
+	return filePath, nil
+}
+
+type workDirServiceStub struct {
+	dir       string
+	keep      bool
+	persisted []string
+	success   *bool
+	onFinish  func()
+}
+
+func (w *workDirServiceStub) Init(dir string, keep bool) (string, error) {
+	w.dir, w.keep = dir, keep
+	return dir, nil
+}
+
+func (w *workDirServiceStub) Persist(_, name string) (string, error) {
+	w.persisted = append(w.persisted, name)
+	return name, nil
+}
+
+func (w *workDirServiceStub) Finish(success bool) error {
+	w.success = &success
+	if w.onFinish != nil {
+		w.onFinish()
+	}
+	return nil
+}
+
+type downloadServiceStub struct {
+	settings download.Settings
+}
+
+func (d *downloadServiceStub) Configure(settings download.Settings) {
+	d.settings = settings
+}
+
+type checksumServiceStub struct {
+	checksums map[string]string
+	lockFile  *checksum.LockFile
+	saved     *checksum.LockFile
+}
+
+func (s *checksumServiceStub) Verify(filePath, source, expected string) (string, error) {
+	actual := s.checksums[filePath]
+	if expected != "" && actual != expected {
+		return "", fmt.Errorf("%s: %w", source, checksum.ErrChecksumMismatch)
+	}
+	return actual, nil
+}
+
+func (s *checksumServiceStub) LoadLockFile(_ string) (*checksum.LockFile, error) {
+	if s.lockFile == nil {
+		return &checksum.LockFile{Checksums: map[string]string{}}, nil
+	}
+	return s.lockFile, nil
+}
+
+func (s *checksumServiceStub) SaveLockFile(_ string, lockFile *checksum.LockFile) error {
+	s.saved = lockFile
+	return nil
+}
+
+type associatedResourcesVerifierStub struct {
+	unknown []*metav1.GroupVersionKind
+}
+
+func (s *associatedResourcesVerifierStub) VerifyAssociatedResources(_ *contentprovider.ModuleConfig,
+	_ string,
+) ([]*metav1.GroupVersionKind, error) {
+	return s.unknown, nil
+}
+
 type manifestServiceStub struct{}
 
 func (*manifestServiceStub) ExtractImagesFromManifest(_ string) ([]string, error) {
@@ -4,11 +4,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/variables"
 	iotools "github.com/kyma-project/modulectl/tools/io"
 )
 
+const (
+	// ConstructorPathsAbsolute references file inputs of the component constructor by absolute paths.
+	ConstructorPathsAbsolute = "absolute"
+	// ConstructorPathsRelative references file inputs by paths relative to the component constructor file.
+	ConstructorPathsRelative = "relative"
+	// ConstructorPathsBundle copies file inputs to a bundle directory next to the component constructor file and
+	// references them by relative paths.
+	ConstructorPathsBundle = "bundle"
+)
+
 type Options struct {
 	Out                       iotools.Out
 	ConfigFile                string
@@ -16,8 +30,32 @@ type Options struct {
 	ModuleSourcesGitDirectory string
 	SkipVersionValidation     bool
 	OutputConstructorFile     string
+	StrictAssociatedResources bool
+	ExpandVariables           bool
+	StrictVariables           bool
+	Variables                 []string
+	PrintEffectiveConfig      bool
+	Profile                   string
+	AllowUnknownFields        bool
+	LockFile                  string
+	Offline                   bool
+	DownloadTimeout           time.Duration
+	DownloadRetries           int
+	WorkDir                   string
+	KeepWorkDir               bool
+	ConstructorPaths          string
+	Release                   bool
+	AllowDirty                bool
+	RequireVersionTag         bool
+	VersionFromTag            bool
+	NoGitSource               bool
+	SourceCommit              string
+	ReferenceRegistry         string
 }
 
+// commitPattern matches full SHA-1 and SHA-256 git commit hashes.
+var commitPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
 func (opts Options) Validate() error {
 	if opts.Out == nil {
 		return fmt.Errorf("opts.Out must not be nil: %w", commonerrors.ErrInvalidOption)
@@ -35,9 +73,14 @@ func (opts Options) Validate() error {
 		return fmt.Errorf("opts.OutputConstructorFile must not be empty: %w", commonerrors.ErrInvalidOption)
 	}
 
-	if opts.ModuleSourcesGitDirectory == "" {
-		return fmt.Errorf("opts.ModuleSourcesGitDirectory must not be empty: %w", commonerrors.ErrInvalidOption)
-	} else {
+	if err := opts.validateGitSource(); err != nil {
+		return err
+	}
+
+	if opts.usesGitRepository() {
+		if opts.ModuleSourcesGitDirectory == "" {
+			return fmt.Errorf("opts.ModuleSourcesGitDirectory must not be empty: %w", commonerrors.ErrInvalidOption)
+		}
 		if isGitDir := isGitDirectory(opts.ModuleSourcesGitDirectory); !isGitDir {
 			return fmt.Errorf("currently configured module-sources-git-directory \"%s\" must point to "+
 				"a valid git repository: %w",
@@ -45,19 +88,78 @@ func (opts Options) Validate() error {
 		}
 	}
 
+	if opts.DownloadTimeout < 0 {
+		return fmt.Errorf("opts.DownloadTimeout must not be negative: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.DownloadRetries < 0 {
+		return fmt.Errorf("opts.DownloadRetries must not be negative: %w", commonerrors.ErrInvalidOption)
+	}
+
+	switch opts.ConstructorPaths {
+	case "", ConstructorPathsAbsolute, ConstructorPathsRelative, ConstructorPathsBundle:
+	default:
+		return fmt.Errorf("opts.ConstructorPaths must be one of %s, %s or %s: %w", ConstructorPathsAbsolute,
+			ConstructorPathsRelative, ConstructorPathsBundle, commonerrors.ErrInvalidOption)
+	}
+
+	if _, err := variables.ParseAssignments(opts.Variables); err != nil {
+		return fmt.Errorf("opts.Variables are invalid: %w", err)
+	}
+
+	return nil
+}
+
+func (opts Options) validateGitSource() error {
+	if opts.NoGitSource && opts.SourceCommit != "" {
+		return fmt.Errorf("opts.NoGitSource and opts.SourceCommit must not be set together: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if opts.SourceCommit != "" && !commitPattern.MatchString(opts.SourceCommit) {
+		return fmt.Errorf("opts.SourceCommit must be a full lowercase hex encoded commit hash: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if !opts.usesGitRepository() && (opts.RequireVersionTag || opts.VersionFromTag) {
+		return fmt.Errorf("opts.RequireVersionTag and opts.VersionFromTag require a git repository: %w",
+			commonerrors.ErrInvalidOption)
+	}
 	return nil
 }
 
+// usesGitRepository returns true if the module sources are read from a git repository, false if no git source is
+// added or the commit of the git source is set explicitly.
+func (opts Options) usesGitRepository() bool {
+	return !opts.NoGitSource && opts.SourceCommit == ""
+}
+
+// expandsVariables returns true if variables must be expanded in the module config, manifest and default CR.
+func (opts Options) expandsVariables() bool {
+	return opts.ExpandVariables || opts.StrictVariables || len(opts.Variables) > 0
+}
+
+// constructorBundleDir returns the directory file inputs are copied to in bundle mode. It is located next to the
+// component constructor file and named after it.
+func (opts Options) constructorBundleDir() string {
+	constructorFile := opts.OutputConstructorFile
+	return strings.TrimSuffix(constructorFile, filepath.Ext(constructorFile)) + "-bundle"
+}
+
+// isGitDirectory returns true if the path is located in a git repository. The .git entry is searched in the path and
+// its parent directories, it is a directory in regular repositories and a file in linked worktrees and submodules.
 func isGitDirectory(path string) bool {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return false
 	}
 
-	gitPath := filepath.Join(absPath, ".git")
-	info, err := os.Stat(gitPath)
-	if err != nil {
-		return false
+	for dir := absPath; ; dir = filepath.Dir(dir) {
+		if _, err = os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return true
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return false
+		}
 	}
-	return info.IsDir()
 }
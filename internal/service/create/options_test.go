@@ -2,7 +2,10 @@ package create_test
 
 import (
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -11,6 +14,11 @@ import (
 )
 
 func Test_Validate_Options(t *testing.T) {
+	nonGitDir := t.TempDir()
+	worktreeDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeDir, ".git"), []byte("gitdir: /repo/.git/worktrees/wt"),
+		0o600))
+
 	tests := []struct {
 		name    string
 		options create.Options
@@ -69,6 +77,18 @@ func Test_Validate_Options(t *testing.T) {
 		},
 		{
 			name: "ModuleSourcesGitDirectory is not a git directory",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputConstructorFile:     "constructor.yaml",
+				ModuleSourcesGitDirectory: nonGitDir,
+			},
+			wantErr: true,
+			errMsg:  "must point to a valid git repository:",
+		},
+		{
+			name: "ModuleSourcesGitDirectory is a subdirectory of a git repository",
 			options: create.Options{
 				Out:                       iotools.NewDefaultOut(io.Discard),
 				ConfigFile:                "config.yaml",
@@ -76,8 +96,132 @@ func Test_Validate_Options(t *testing.T) {
 				OutputConstructorFile:     "constructor.yaml",
 				ModuleSourcesGitDirectory: ".",
 			},
+			wantErr: false,
+		},
+		{
+			name: "ModuleSourcesGitDirectory is a linked worktree",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputConstructorFile:     "constructor.yaml",
+				ModuleSourcesGitDirectory: worktreeDir,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Variables are not in KEY=VALUE format",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputConstructorFile:     "constructor.yaml",
+				ModuleSourcesGitDirectory: "../../../",
+				Variables:                 []string{"MODULE_VERSION"},
+			},
+			wantErr: true,
+			errMsg:  "opts.Variables are invalid",
+		},
+		{
+			name: "DownloadTimeout is negative",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputConstructorFile:     "constructor.yaml",
+				ModuleSourcesGitDirectory: "../../../",
+				DownloadTimeout:           -time.Second,
+			},
 			wantErr: true,
-			errMsg:  "currently configured module-sources-git-directory \".\" must point to a valid git repository:",
+			errMsg:  "opts.DownloadTimeout must not be negative",
+		},
+		{
+			name: "DownloadRetries is negative",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputConstructorFile:     "constructor.yaml",
+				ModuleSourcesGitDirectory: "../../../",
+				DownloadRetries:           -1,
+			},
+			wantErr: true,
+			errMsg:  "opts.DownloadRetries must not be negative",
+		},
+		{
+			name: "ConstructorPaths is unknown",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputConstructorFile:     "constructor.yaml",
+				ModuleSourcesGitDirectory: "../../../",
+				ConstructorPaths:          "portable",
+			},
+			wantErr: true,
+			errMsg:  "opts.ConstructorPaths must be one of absolute, relative or bundle",
+		},
+		{
+			name: "NoGitSource and SourceCommit are both set",
+			options: create.Options{
+				Out:                   iotools.NewDefaultOut(io.Discard),
+				ConfigFile:            "config.yaml",
+				TemplateOutput:        "output",
+				OutputConstructorFile: "constructor.yaml",
+				NoGitSource:           true,
+				SourceCommit:          "0123456789abcdef0123456789abcdef01234567",
+			},
+			wantErr: true,
+			errMsg:  "opts.NoGitSource and opts.SourceCommit must not be set together",
+		},
+		{
+			name: "SourceCommit is not a full commit hash",
+			options: create.Options{
+				Out:                   iotools.NewDefaultOut(io.Discard),
+				ConfigFile:            "config.yaml",
+				TemplateOutput:        "output",
+				OutputConstructorFile: "constructor.yaml",
+				SourceCommit:          "0123456",
+			},
+			wantErr: true,
+			errMsg:  "opts.SourceCommit must be a full lowercase hex encoded commit hash",
+		},
+		{
+			name: "VersionFromTag without git repository",
+			options: create.Options{
+				Out:                   iotools.NewDefaultOut(io.Discard),
+				ConfigFile:            "config.yaml",
+				TemplateOutput:        "output",
+				OutputConstructorFile: "constructor.yaml",
+				NoGitSource:           true,
+				VersionFromTag:        true,
+			},
+			wantErr: true,
+			errMsg:  "opts.RequireVersionTag and opts.VersionFromTag require a git repository",
+		},
+		{
+			name: "NoGitSource does not require a git directory",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputConstructorFile:     "constructor.yaml",
+				ModuleSourcesGitDirectory: nonGitDir,
+				NoGitSource:               true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "SourceCommit does not require a git directory",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputConstructorFile:     "constructor.yaml",
+				ModuleSourcesGitDirectory: nonGitDir,
+				SourceCommit:              "0123456789abcdef0123456789abcdef01234567",
+			},
+			wantErr: false,
 		},
 		{
 			name: "All fields valid",
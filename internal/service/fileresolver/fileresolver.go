@@ -11,13 +11,20 @@ import (
 
 type TempFileSystem interface {
 	DownloadTempFile(dir, pattern string, url *url.URL) (string, error)
+	WriteTempFile(dir, pattern string, bytes []byte) (string, error)
 	FileExists(filePath string) (bool, error)
 	RemoveTempFiles() []error
 }
 
+// Fetcher fetches the content of a file referenced by a URL with a scheme other than https.
+type Fetcher interface {
+	Fetch(fileURL *url.URL) ([]byte, error)
+}
+
 type FileResolver struct {
 	filePattern    string
 	tempFileSystem TempFileSystem
+	fetchers       map[string]Fetcher
 }
 
 func NewFileResolver(filePattern string, tempFileSystem TempFileSystem) (*FileResolver, error) {
@@ -32,14 +39,28 @@ func NewFileResolver(filePattern string, tempFileSystem TempFileSystem) (*FileRe
 	return &FileResolver{
 		filePattern:    filePattern,
 		tempFileSystem: tempFileSystem,
+		fetchers:       map[string]Fetcher{},
 	}, nil
 }
 
+// WithFetcher registers the fetcher for file references using the URL scheme.
+func (r *FileResolver) WithFetcher(scheme string, fetcher Fetcher) *FileResolver {
+	r.fetchers[scheme] = fetcher
+	return r
+}
+
 func (r *FileResolver) Resolve(fileRef contentprovider.UrlOrLocalFile, basePath string) (string, error) {
 	if fileRef.IsEmpty() {
 		return "", fmt.Errorf("file reference is empty: %w", commonerrors.ErrInvalidArg)
 	}
 	if fileRef.IsURL() {
+		if fetcher, ok := r.fetchers[fileRef.URL().Scheme]; ok {
+			return r.fetchTempFile(fetcher, fileRef.URL())
+		}
+		if fileRef.URL().Scheme != contentprovider.SchemeHTTPS {
+			return "", fmt.Errorf("unsupported URL scheme %q of %s: %w", fileRef.URL().Scheme,
+				fileRef.URL().Redacted(), commonerrors.ErrInvalidArg)
+		}
 		tempFilePath, err := r.tempFileSystem.DownloadTempFile("", r.filePattern, fileRef.URL())
 		if err != nil {
 			return "", fmt.Errorf("failed to download file: %w", err)
@@ -58,6 +79,18 @@ func (r *FileResolver) Resolve(fileRef contentprovider.UrlOrLocalFile, basePath
 	}
 }
 
+func (r *FileResolver) fetchTempFile(fetcher Fetcher, fileURL *url.URL) (string, error) {
+	data, err := fetcher.Fetch(fileURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch file from %s: %w", fileURL.Redacted(), err)
+	}
+	tempFilePath, err := r.tempFileSystem.WriteTempFile("", r.filePattern, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to write fetched file: %w", err)
+	}
+	return tempFilePath, nil
+}
+
 func (r *FileResolver) CleanupTempFiles() []error {
 	return r.tempFileSystem.RemoveTempFiles()
 }
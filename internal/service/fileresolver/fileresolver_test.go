@@ -60,6 +60,40 @@ func Test_Resolve_WhenURL_Returns_Error_WhenFailingToDownload(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func Test_Resolve_WhenURL_WithRegisteredScheme_WritesFetchedFile(t *testing.T) {
+	tmpFileSystem := &tmpfileSystemStub{}
+	fetcher := &fetcherStub{data: []byte("manifest")}
+	resolver, _ := fileresolver.NewFileResolver(filePattern, tmpFileSystem)
+	resolver = resolver.WithFetcher(contentprovider.SchemeOCI, fetcher)
+
+	result, err := resolver.Resolve(urlOrFileRef("oci://registry.example.com/manifests:1.0.0"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "fetched.yaml", result)
+	assert.Equal(t, "oci://registry.example.com/manifests:1.0.0", fetcher.fetched)
+	assert.Equal(t, "manifest", string(tmpFileSystem.written))
+}
+
+func Test_Resolve_WhenURL_Returns_Error_WhenFailingToFetch(t *testing.T) {
+	resolver, _ := fileresolver.NewFileResolver(filePattern, &tmpfileSystemStub{})
+	resolver = resolver.WithFetcher(contentprovider.SchemeGitHTTPS, &fetcherStub{err: errors.New("error fetching file")})
+
+	result, err := resolver.Resolve(urlOrFileRef("git+https://example.com/repo//manifest.yaml@v1"), "")
+
+	require.ErrorContains(t, err, "failed to fetch file from git+https://example.com/repo//manifest.yaml@v1")
+	assert.Empty(t, result)
+}
+
+func Test_Resolve_WhenURL_Returns_Error_WhenSchemeIsNotSupported(t *testing.T) {
+	resolver, _ := fileresolver.NewFileResolver(filePattern, &tmpfileSystemStub{})
+
+	result, err := resolver.Resolve(urlOrFileRef("oci://registry.example.com/manifests:1.0.0"), "")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), `unsupported URL scheme "oci"`)
+	assert.Empty(t, result)
+}
+
 func Test_Resolve_WhenLocalFile_WithJustFilename(t *testing.T) {
 	resolver, _ := fileresolver.NewFileResolver(filePattern, &tmpfileSystemStub{})
 	fileRef := urlOrFileRef("manifest.yaml")
@@ -174,12 +208,19 @@ func Test_Resolve_WhenLocalFile_Returns_Error_WhenFileDoesNotExist(t *testing.T)
 	assert.Empty(t, result)
 }
 
-type tmpfileSystemStub struct{}
+type tmpfileSystemStub struct {
+	written []byte
+}
 
 func (*tmpfileSystemStub) DownloadTempFile(_ string, _ string, _ *url.URL) (string, error) {
 	return "file.yaml", nil
 }
 
+func (s *tmpfileSystemStub) WriteTempFile(_ string, _ string, bytes []byte) (string, error) {
+	s.written = bytes
+	return "fetched.yaml", nil
+}
+
 func (s *tmpfileSystemStub) FileExists(filePath string) (bool, error) {
 	if strings.Contains(filePath, "path/to/manifest.yaml") || strings.Contains(filePath, "manifest.yaml") {
 		return true, nil
@@ -197,6 +238,10 @@ func (*tempfileSystemErrorStub) DownloadTempFile(_ string, _ string, _ *url.URL)
 	return "", errors.New("error downloading file")
 }
 
+func (*tempfileSystemErrorStub) WriteTempFile(_ string, _ string, _ []byte) (string, error) {
+	return "", errors.New("error writing file")
+}
+
 func (s *tempfileSystemErrorStub) FileExists(filePath string) (bool, error) {
 	if strings.HasPrefix(filePath, "notexist") {
 		return false, nil
@@ -207,3 +252,14 @@ func (s *tempfileSystemErrorStub) FileExists(filePath string) (bool, error) {
 func (s *tempfileSystemErrorStub) RemoveTempFiles() []error {
 	return nil
 }
+
+type fetcherStub struct {
+	data    []byte
+	err     error
+	fetched string
+}
+
+func (s *fetcherStub) Fetch(fileURL *url.URL) ([]byte, error) {
+	s.fetched = fileURL.String()
+	return s.data, s.err
+}
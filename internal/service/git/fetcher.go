@@ -0,0 +1,209 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/kyma-project/modulectl/tools/download"
+	"github.com/kyma-project/modulectl/tools/httpauth"
+)
+
+const (
+	gitHTTPSScheme = "git+https"
+	// tokenUsername is the username sent with bearer tokens, which git hosting services expect as basic auth password.
+	tokenUsername = "token"
+)
+
+var ErrInvalidGitReference = errors.New("invalid git reference")
+
+// Fetcher fetches files from git repositories referenced as git+https://host/repository//path/to/file@ref. The ref
+// can be a branch, a tag or a commit and defaults to the HEAD of the repository.
+type Fetcher struct {
+	credentials      httpauth.CredentialLookup
+	downloadSettings download.SettingsProvider
+}
+
+func NewFetcher() *Fetcher {
+	return &Fetcher{}
+}
+
+// WithCredentials authenticates against git hosts with the credentials of the host.
+func (f *Fetcher) WithCredentials(credentials httpauth.CredentialLookup) *Fetcher {
+	f.credentials = credentials
+	return f
+}
+
+// WithDownloadSettings applies the timeout and the offline mode of the download settings to all fetches.
+func (f *Fetcher) WithDownloadSettings(downloadSettings download.SettingsProvider) *Fetcher {
+	f.downloadSettings = downloadSettings
+	return f
+}
+
+func (f *Fetcher) Fetch(fileURL *url.URL) ([]byte, error) {
+	repoURL, filePath, ref, err := ParseReference(fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := f.auth(fileURL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, err := download.ContextFor(f.downloadSettings, fileURL.Redacted())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	return FetchFile(ctx, repoURL, filePath, ref, auth)
+}
+
+// ParseReference splits the git+https:// URL into the URL of the repository, the path of the file in the repository
+// and the ref, which is empty if not set.
+func ParseReference(fileURL *url.URL) (string, string, string, error) {
+	if fileURL.Scheme != gitHTTPSScheme || fileURL.User != nil || fileURL.RawQuery != "" || fileURL.Fragment != "" {
+		return "", "", "", fmt.Errorf("'%s' must be in the format git+https://host/repository//path@ref: %w",
+			fileURL.Redacted(), ErrInvalidGitReference)
+	}
+
+	repoPath, fileRef, found := strings.Cut(fileURL.Path, "//")
+	if !found || strings.Trim(repoPath, "/") == "" {
+		return "", "", "", fmt.Errorf("'%s' must separate the repository and the file path with '//': %w",
+			fileURL.Redacted(), ErrInvalidGitReference)
+	}
+
+	filePath, ref := fileRef, ""
+	if index := strings.LastIndex(fileRef, "@"); index >= 0 {
+		filePath, ref = fileRef[:index], fileRef[index+1:]
+		if ref == "" {
+			return "", "", "", fmt.Errorf("'%s' must not have an empty ref: %w", fileURL.Redacted(),
+				ErrInvalidGitReference)
+		}
+	}
+	if filePath == "" {
+		return "", "", "", fmt.Errorf("'%s' must reference a file in the repository: %w", fileURL.Redacted(),
+			ErrInvalidGitReference)
+	}
+
+	repoURL := url.URL{Scheme: "https", Host: fileURL.Host, Path: repoPath}
+	return repoURL.String(), filePath, ref, nil
+}
+
+// FetchFile clones the repository into memory and returns the content of the file at the ref. An empty ref refers to
+// the HEAD of the repository. Branches and tags are cloned shallowly with only the requested ref. Any other ref is
+// treated as a possibly abbreviated commit, which requires a clone of the full history of all branches.
+func FetchFile(ctx context.Context, repoURL, filePath, ref string, auth transport.AuthMethod) ([]byte, error) {
+	refName, err := findRemoteRef(ctx, repoURL, ref, auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refs of repository %s: %w", repoURL, err)
+	}
+
+	cloneOptions := &git.CloneOptions{
+		URL:  repoURL,
+		Auth: auth,
+		Tags: git.NoTags,
+	}
+	if refName != "" {
+		cloneOptions.ReferenceName = refName
+		cloneOptions.SingleBranch = true
+		cloneOptions.Depth = 1
+	}
+
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), nil, cloneOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository %s: %w", repoURL, err)
+	}
+
+	hash, err := resolveRef(repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve ref %q in repository %s: %w", ref, repoURL, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s in repository %s: %w", hash, repoURL, err)
+	}
+
+	file, err := commit.File(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file %s at %s in repository %s: %w", filePath, hash, repoURL, err)
+	}
+
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s at %s in repository %s: %w", filePath, hash, repoURL, err)
+	}
+	return []byte(contents), nil
+}
+
+// findRemoteRef returns the name of the branch or tag of the remote repository that matches the ref, HEAD for an
+// empty ref, or an empty name if the ref is neither a branch nor a tag.
+func findRemoteRef(ctx context.Context, repoURL, ref string, auth transport.AuthMethod) (plumbing.ReferenceName,
+	error,
+) {
+	if ref == "" {
+		return plumbing.HEAD, nil
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{repoURL},
+	})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		return "", err
+	}
+
+	candidates := []plumbing.ReferenceName{plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)}
+	for _, candidate := range candidates {
+		for _, remoteRef := range refs {
+			if remoteRef.Name() == candidate {
+				return candidate, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// resolveRef resolves the ref, which can be a tag, a commit, or a branch that is only available as remote branch in
+// the cloned repository.
+func resolveRef(repo *git.Repository, ref string) (*plumbing.Hash, error) {
+	if ref == "" {
+		ref = string(plumbing.HEAD)
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return repo.ResolveRevision(plumbing.Revision(git.DefaultRemoteName + "/" + ref))
+	}
+	return hash, err
+}
+
+func (f *Fetcher) auth(host string) (transport.AuthMethod, error) {
+	if f.credentials == nil {
+		return nil, nil //nolint:nilnil // no credentials means anonymous access
+	}
+
+	credential, found, err := f.credentials.Lookup(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up credentials for %s: %w", host, err)
+	}
+	if !found {
+		return nil, nil //nolint:nilnil // no credentials means anonymous access
+	}
+
+	if credential.Token != "" {
+		return &githttp.BasicAuth{Username: tokenUsername, Password: credential.Token}, nil
+	}
+	return &githttp.BasicAuth{Username: credential.Username, Password: credential.Password}, nil
+}
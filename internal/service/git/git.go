@@ -1,13 +1,35 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/kyma-project/modulectl/internal/common/types"
+)
+
+var ErrUnsupportedRemoteURL = errors.New("unsupported git remote URL")
+
+// scpLikeURLPattern matches SSH remote URLs in the scp-like syntax, such as git@github.com:kyma-project/modulectl.git.
+var scpLikeURLPattern = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+const (
+	SignatureTypeGPG  = "gpg"
+	SignatureTypeSSH  = "ssh"
+	SignatureTypeX509 = "x509"
 )
 
 type Service struct {
-	latestCommit string
+	latestCommit       string
+	uncommittedChanges []string
+	statusLoaded       bool
 }
 
 func NewService() *Service {
@@ -19,7 +41,7 @@ func (s *Service) GetLatestCommit(gitRepoPath string) (string, error) {
 		return s.latestCommit, nil
 	}
 
-	repo, err := git.PlainOpen(gitRepoPath)
+	repo, err := openRepository(gitRepoPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to open repo: %w", err)
 	}
@@ -33,3 +55,235 @@ func (s *Service) GetLatestCommit(gitRepoPath string) (string, error) {
 
 	return s.latestCommit, nil
 }
+
+// GetUncommittedChanges returns the sorted paths of tracked files that have staged or unstaged changes in the
+// working tree. Untracked files are not considered changes. If the path is a subdirectory of the repository, only
+// changes in that subdirectory are returned. The paths are relative to the root of the repository.
+func (s *Service) GetUncommittedChanges(gitRepoPath string) ([]string, error) {
+	if s.statusLoaded {
+		return s.uncommittedChanges, nil
+	}
+
+	repo, err := openRepository(gitRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repo: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	subdirectory, err := getSubdirectory(repo, gitRepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+
+	changes := make([]string, 0)
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Untracked || fileStatus.Staging == git.Untracked {
+			continue
+		}
+		if subdirectory != "" && !strings.HasPrefix(path, subdirectory+"/") {
+			continue
+		}
+		if fileStatus.Worktree != git.Unmodified || fileStatus.Staging != git.Unmodified {
+			changes = append(changes, path)
+		}
+	}
+	slices.Sort(changes)
+
+	s.uncommittedChanges, s.statusLoaded = changes, true
+	return s.uncommittedChanges, nil
+}
+
+// GetMetadata returns the branch, tags, commit time and signature types of the HEAD commit. Signatures are not
+// verified, so the signature types are not a trust signal.
+func (s *Service) GetMetadata(gitRepoPath string) (*types.GitMetadata, error) {
+	repo, err := openRepository(gitRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repo: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head: %w", err)
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head commit: %w", err)
+	}
+
+	subdirectory, err := getSubdirectory(repo, gitRepoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &types.GitMetadata{
+		Subdirectory:        subdirectory,
+		CommitTime:          commit.Committer.When,
+		CommitSignatureType: signatureType(commit.PGPSignature),
+		TagSignatureTypes:   map[string]string{},
+	}
+	if head.Name().IsBranch() {
+		metadata.Branch = head.Name().Short()
+	}
+
+	if err = addHeadTags(repo, head.Hash(), metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// openRepository opens the repository containing the path. The path may be a subdirectory of the repository, the
+// root of a linked worktree or a submodule, where .git is a file referencing the git directory.
+func openRepository(path string) (*git.Repository, error) {
+	return git.PlainOpenWithOptions(path, &git.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+}
+
+// getSubdirectory returns the slash-separated path of the directory relative to the root of the worktree, or an empty
+// string if the directory is the root.
+func getSubdirectory(repo *git.Repository, dir string) (string, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	root, err := filepath.EvalSymlinks(worktree.Filesystem.Root())
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve worktree root: %w", err)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path of %s: %w", dir, err)
+	}
+	if absDir, err = filepath.EvalSymlinks(absDir); err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	subdirectory, err := filepath.Rel(root, absDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get path of %s relative to the worktree root: %w", dir, err)
+	}
+	if subdirectory == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(subdirectory), nil
+}
+
+// addHeadTags adds the lightweight and annotated tags pointing at the head commit and the signatures of the
+// annotated tags to the metadata.
+func addHeadTags(repo *git.Repository, head plumbing.Hash, metadata *types.GitMetadata) error {
+	tags, err := repo.Tags()
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		tag, err := repo.TagObject(ref.Hash())
+		switch {
+		case errors.Is(err, plumbing.ErrObjectNotFound):
+			if ref.Hash() == head {
+				metadata.Tags = append(metadata.Tags, name)
+			}
+			return nil
+		case err != nil:
+			return fmt.Errorf("failed to get tag %s: %w", name, err)
+		}
+
+		if tag.TargetType != plumbing.CommitObject || tag.Target != head {
+			return nil
+		}
+		metadata.Tags = append(metadata.Tags, name)
+		if signature := signatureType(tag.PGPSignature); signature != "" {
+			metadata.TagSignatureTypes[name] = signature
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get tags of head: %w", err)
+	}
+
+	slices.Sort(metadata.Tags)
+	return nil
+}
+
+// signatureType returns the type of an armored commit or tag signature, or an empty string if there is no signature.
+func signatureType(signature string) string {
+	switch {
+	case signature == "":
+		return ""
+	case strings.Contains(signature, "BEGIN SSH SIGNATURE"):
+		return SignatureTypeSSH
+	case strings.Contains(signature, "BEGIN SIGNED MESSAGE"):
+		return SignatureTypeX509
+	default:
+		return SignatureTypeGPG
+	}
+}
+
+// GetRemoteRepositoryURL returns the URL of the remote as https URL of the repository, see NormalizeRepositoryURL.
+func (s *Service) GetRemoteRepositoryURL(gitRepoPath, remoteName string) (string, error) {
+	repo, err := openRepository(gitRepoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repo: %w", err)
+	}
+
+	remote, err := repo.Remote(remoteName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get remote %s: %w", remoteName, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("remote %s has no URL: %w", remoteName, ErrUnsupportedRemoteURL)
+	}
+
+	repositoryURL, err := NormalizeRepositoryURL(urls[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to normalize URL of remote %s: %w", remoteName, err)
+	}
+	return repositoryURL, nil
+}
+
+// NormalizeRepositoryURL converts a git remote URL to the https URL of the repository. SSH URLs, including the
+// scp-like syntax git@host:org/repo.git, and http or git URLs are converted to https. User info, a trailing slash
+// and the .git suffix are removed.
+func NormalizeRepositoryURL(remoteURL string) (string, error) {
+	var host, repoPath string
+	if matches := scpLikeURLPattern.FindStringSubmatch(remoteURL); matches != nil && !strings.Contains(remoteURL, "://") {
+		host, repoPath = matches[1], matches[2]
+	} else {
+		parsedURL, err := url.Parse(remoteURL)
+		if err != nil {
+			// The URL is not printed as it may contain credentials.
+			return "", fmt.Errorf("remote URL is not a valid URL: %w", ErrUnsupportedRemoteURL)
+		}
+		switch parsedURL.Scheme {
+		case "https", "http", "ssh", "git", "git+ssh", "ssh+git":
+		default:
+			return "", fmt.Errorf("'%s' is not using https, http, ssh or git scheme: %w", parsedURL.Redacted(),
+				ErrUnsupportedRemoteURL)
+		}
+		host, repoPath = parsedURL.Hostname(), parsedURL.Path
+		// Keep non-default ports of web URLs, ports of other schemes refer to other services.
+		if port := parsedURL.Port(); port != "" && (parsedURL.Scheme == "https" || parsedURL.Scheme == "http") {
+			host += ":" + port
+		}
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if host == "" || repoPath == "" {
+		return "", fmt.Errorf("remote URL does not reference a repository: %w", ErrUnsupportedRemoteURL)
+	}
+	return (&url.URL{Scheme: "https", Host: strings.ToLower(host), Path: "/" + repoPath}).String(), nil
+}
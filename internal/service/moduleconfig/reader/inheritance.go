@@ -0,0 +1,347 @@
+package moduleconfigreader
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/variables"
+)
+
+const extendsKey = "extends"
+
+var (
+	ErrExtendsCycle        = errors.New("module config inheritance cycle detected")
+	ErrInvalidExtends      = errors.New("invalid extends reference")
+	ErrMissingFileResolver = errors.New("no file resolver configured to resolve extends reference")
+	ErrInvalidConfig       = errors.New("invalid module config")
+)
+
+// namedListKeys are the module config attributes that may be provided either as a map or as a list of name/link
+// items. They are merged by name.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var namedListKeys = []string{"icons", "resources"}
+
+// loadEffectiveConfig reads the module config file and all base configs referenced by the extends attribute and
+// returns the deep-merged result. Merge rules:
+//   - maps are merged recursively, the values of the extending config take precedence
+//   - icons and resources are merged by name
+//   - scalars and lists of the extending config replace the values of the base config
+//   - a null value in the extending config removes the attribute inherited from the base config
+//
+// Afterwards, the overlay of the given profile is applied to the merged config.
+func loadEffectiveConfig(configFilePath string, fileSystem FileSystem, fileResolver FileResolver,
+	opts Options,
+) (*yaml.Node, error) {
+	// both passes read the same files, cache them so that the standard input is consumed and remote base configs are
+	// downloaded only once
+	fileSystem = &cachedFileSystem{FileSystem: fileSystem, files: map[string][]byte{}}
+	if fileResolver != nil {
+		fileResolver = &cachedFileResolver{FileResolver: fileResolver, paths: map[resolveKey]string{}}
+	}
+
+	if opts.Variables != nil {
+		if err := setModuleVariables(configFilePath, fileSystem, fileResolver, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	config, err := loadConfig(contentprovider.UrlOrLocalFile{}, configFilePath, fileSystem, fileResolver,
+		opts.Variables, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if config, err = applyProfile(config, opts.Profile); err != nil {
+		return nil, fmt.Errorf("failed to apply profile: %w", err)
+	}
+	return config, nil
+}
+
+// setModuleVariables reads the module name and version in a first pass over the module config and sets them as
+// defaults of the MODULE_NAME and MODULE_VERSION variables, so they can be referenced in the module config itself. A
+// name or version that references an undefined variable does not set a default.
+func setModuleVariables(configFilePath string, fileSystem FileSystem, fileResolver FileResolver,
+	opts Options,
+) error {
+	config, err := loadConfig(contentprovider.UrlOrLocalFile{}, configFilePath, fileSystem, fileResolver,
+		opts.Variables.Lenient(), nil)
+	if err != nil {
+		return err
+	}
+	if config, err = applyProfile(config, opts.Profile); err != nil {
+		return fmt.Errorf("failed to apply profile: %w", err)
+	}
+
+	if name, ok := scalarValue(config, "name"); ok {
+		opts.Variables.SetDefault(variables.ModuleNameVariable, name)
+	}
+	version, ok := opts.Version, opts.Version != ""
+	if !ok {
+		version, ok = scalarValue(config, "version")
+	}
+	if ok {
+		opts.Variables.SetDefault(variables.ModuleVersionVariable, version)
+	}
+	return nil
+}
+
+// scalarValue returns the value of the scalar attribute of the mapping node. It returns false if the attribute does
+// not exist, is not a scalar or still contains a variable reference.
+func scalarValue(mapping *yaml.Node, key string) (string, bool) {
+	index := findKey(mapping, key)
+	if index < 0 {
+		return "", false
+	}
+	value := mapping.Content[index+1]
+	if value.Kind != yaml.ScalarNode || value.Value == "" || strings.Contains(value.Value, "${") {
+		return "", false
+	}
+	return value.Value, true
+}
+
+func loadConfig(location contentprovider.UrlOrLocalFile, filePath string, fileSystem FileSystem,
+	fileResolver FileResolver, vars *variables.Variables, visited []string,
+) (*yaml.Node, error) {
+	id := location.String()
+	if id == "" {
+		id = path.Clean(filePath)
+	}
+	if slices.Contains(visited, id) {
+		return nil, fmt.Errorf("%s extends %s: %w", visited[len(visited)-1], id, ErrExtendsCycle)
+	}
+	visited = append(visited, id)
+
+	data, err := fileSystem.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module config file: %w", err)
+	}
+
+	if vars != nil {
+		if data, err = vars.Expand(data); err != nil {
+			return nil, fmt.Errorf("failed to expand variables in module config file %s: %w", id, err)
+		}
+	}
+
+	config, err := parseConfigNode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse module config file %s: %w", id, err)
+	}
+	normalizeNamedLists(config)
+
+	extends := removeKey(config, extendsKey)
+	if extends == nil {
+		return config, nil
+	}
+
+	if extends.Kind != yaml.ScalarNode || extends.Tag != "!!str" || extends.Value == "" {
+		return nil, fmt.Errorf("%s: must be a non-empty string: %w", id, ErrInvalidExtends)
+	}
+	if fileResolver == nil {
+		return nil, ErrMissingFileResolver
+	}
+
+	baseLocation, baseFilePath, err := resolveBase(extends.Value, location, filePath, fileResolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base config %q of %s: %w", extends.Value, id, err)
+	}
+
+	base, err := loadConfig(baseLocation, baseFilePath, fileSystem, fileResolver, vars, visited)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeConfig(base, config), nil
+}
+
+// cachedFileSystem returns the content of a file that was read before instead of reading it again.
+type cachedFileSystem struct {
+	FileSystem
+
+	files map[string][]byte
+}
+
+func (f *cachedFileSystem) ReadFile(path string) ([]byte, error) {
+	if data, ok := f.files[path]; ok {
+		return data, nil
+	}
+	data, err := f.FileSystem.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f.files[path] = data
+	return data, nil
+}
+
+// cachedFileResolver returns the path of a file reference that was resolved before instead of resolving it again.
+type cachedFileResolver struct {
+	FileResolver
+
+	paths map[resolveKey]string
+}
+
+type resolveKey struct {
+	fileRef  string
+	basePath string
+}
+
+func (r *cachedFileResolver) Resolve(fileRef contentprovider.UrlOrLocalFile, basePath string) (string, error) {
+	key := resolveKey{fileRef: fileRef.String(), basePath: basePath}
+	if resolvedPath, ok := r.paths[key]; ok {
+		return resolvedPath, nil
+	}
+	resolvedPath, err := r.FileResolver.Resolve(fileRef, basePath)
+	if err != nil {
+		return "", err
+	}
+	r.paths[key] = resolvedPath
+	return resolvedPath, nil
+}
+
+// parseConfigNode parses the YAML or JSON config into a mapping node. A JSON config is parsed as YAML after it has
+// been validated as JSON. An empty config results in an empty mapping node.
+func parseConfigNode(data []byte) (*yaml.Node, error) {
+	if isJSON(data) {
+		if err := validateJSON(data); err != nil {
+			return nil, err
+		}
+		data = unescapeSlashes(data)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+
+	config := document.Content[0]
+	if config.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: module config must be a map: %w", config.Line, ErrInvalidConfig)
+	}
+	return config, nil
+}
+
+// resolveBase resolves the extends reference of a config. Relative references are resolved relative to the location
+// of the extending config, which is either a URL or a local file.
+func resolveBase(ref string, location contentprovider.UrlOrLocalFile, filePath string,
+	fileResolver FileResolver,
+) (contentprovider.UrlOrLocalFile, string, error) {
+	var baseRef contentprovider.UrlOrLocalFile
+	if err := baseRef.FromString(ref); err != nil {
+		return baseRef, "", fmt.Errorf("%w: %w", ErrInvalidExtends, err)
+	}
+
+	if baseRef.IsURL() && baseRef.URL().Scheme != "https" {
+		return baseRef, "", fmt.Errorf("'%s' is not using https scheme: %w", ref, ErrInvalidExtends)
+	}
+
+	if !baseRef.IsURL() && location.IsURL() && !path.IsAbs(ref) {
+		refURL, err := url.Parse(ref)
+		if err != nil {
+			return baseRef, "", fmt.Errorf("%w: %w", ErrInvalidExtends, err)
+		}
+		if err := baseRef.FromString(location.URL().ResolveReference(refURL).String()); err != nil {
+			return baseRef, "", fmt.Errorf("%w: %w", ErrInvalidExtends, err)
+		}
+	}
+
+	resolvedPath, err := fileResolver.Resolve(baseRef, path.Dir(filePath))
+	if err != nil {
+		return baseRef, "", fmt.Errorf("failed to resolve file: %w", err)
+	}
+
+	if !baseRef.IsURL() {
+		return contentprovider.UrlOrLocalFile{}, resolvedPath, nil
+	}
+	return baseRef, resolvedPath, nil
+}
+
+// mergeConfig merges the override mapping node into the base mapping node and returns the result.
+func mergeConfig(base, override *yaml.Node) *yaml.Node {
+	result := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	result.Content = slices.Clone(base.Content)
+
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, value := override.Content[i], override.Content[i+1]
+
+		if value.Tag == "!!null" {
+			removeKey(result, key.Value)
+			continue
+		}
+
+		index := findKey(result, key.Value)
+		if index < 0 {
+			result.Content = append(result.Content, key, value)
+			continue
+		}
+
+		if baseValue := result.Content[index+1]; baseValue.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+			value = mergeConfig(baseValue, value)
+		}
+		result.Content[index+1] = value
+	}
+	return result
+}
+
+func findKey(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeKey removes the key from the mapping node and returns its value, or nil if the key does not exist.
+func removeKey(mapping *yaml.Node, key string) *yaml.Node {
+	index := findKey(mapping, key)
+	if index < 0 {
+		return nil
+	}
+	value := mapping.Content[index+1]
+	mapping.Content = slices.Delete(mapping.Content, index, index+2)
+	return value
+}
+
+// normalizeNamedLists converts the list representation of icons and resources to maps, so that they can be merged
+// by name. Lists with duplicate or missing names are kept, so that parsing the config reports them.
+func normalizeNamedLists(config *yaml.Node) {
+	for _, key := range namedListKeys {
+		index := findKey(config, key)
+		if index < 0 || config.Content[index+1].Kind != yaml.SequenceNode {
+			continue
+		}
+		if entries, ok := namedListToMap(config.Content[index+1]); ok {
+			config.Content[index+1] = entries
+		}
+	}
+}
+
+func namedListToMap(items *yaml.Node) (*yaml.Node, bool) {
+	entries := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, item := range items.Content {
+		if item.Kind != yaml.MappingNode {
+			return nil, false
+		}
+		nameIndex := findKey(item, "name")
+		linkIndex := findKey(item, "link")
+		if nameIndex < 0 || linkIndex < 0 {
+			return nil, false
+		}
+		name := item.Content[nameIndex+1]
+		if findKey(entries, name.Value) >= 0 {
+			return nil, false
+		}
+		entries.Content = append(entries.Content, name, item.Content[linkIndex+1])
+	}
+	return entries, true
+}
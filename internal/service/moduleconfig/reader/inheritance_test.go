@@ -0,0 +1,258 @@
+package moduleconfigreader_test
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/variables"
+)
+
+const baseConfig = `
+team: kyma/jellyfish
+documentation: https://kyma-project.io/docs
+icons:
+- name: module-icon
+  link: https://kyma-project.io/icon.svg
+labels:
+  shared-label: base
+  overridden-label: base
+annotations:
+  removed-annotation: base
+associatedResources:
+- group: networking.istio.io
+  version: v1alpha3
+  kind: Gateway
+requiresDowntime: true
+`
+
+func Test_ParseModuleConfig_MergesBaseConfig(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"modules/sample/module-config.yaml": `
+extends: ../../shared/base.yaml
+name: kyma-project.io/module/sample
+version: 1.0
+manifest: manifest.yaml
+icons:
+  other-icon: https://kyma-project.io/other.svg
+labels:
+  overridden-label: module
+annotations:
+  removed-annotation: null
+associatedResources:
+- group: operator.kyma-project.io
+  version: v1
+  kind: Sample
+requiresDowntime: false
+`,
+		"shared/base.yaml": baseConfig,
+	}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("modules/sample/module-config.yaml", fs,
+		&fileResolverStub{fileSystem: fs}, moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma-project.io/module/sample", result.Name)
+	require.Equal(t, "1.0", result.Version)
+	require.Equal(t, "manifest.yaml", result.Manifest.String())
+	require.Equal(t, "kyma/jellyfish", result.Team)
+	require.Equal(t, "https://kyma-project.io/docs", result.Documentation)
+	require.Equal(t, contentprovider.Icons{
+		"module-icon": "https://kyma-project.io/icon.svg",
+		"other-icon":  "https://kyma-project.io/other.svg",
+	}, result.Icons)
+	require.Equal(t, map[string]string{"shared-label": "base", "overridden-label": "module"}, result.Labels)
+	require.Empty(t, result.Annotations)
+	require.Len(t, result.AssociatedResources, 1)
+	require.Equal(t, "Sample", result.AssociatedResources[0].Kind)
+	require.False(t, result.RequiresDowntime)
+}
+
+func Test_ParseModuleConfig_MergesNestedBaseConfigs(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "extends: team.yaml\nname: kyma-project.io/module/sample",
+		"team.yaml":          "extends: base.yaml\nteam: kyma/goat",
+		"base.yaml":          baseConfig,
+	}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma-project.io/module/sample", result.Name)
+	require.Equal(t, "kyma/goat", result.Team)
+	require.Equal(t, "https://kyma-project.io/docs", result.Documentation)
+}
+
+func Test_ParseModuleConfig_ResolvesRelativeExtendsOfRemoteBaseConfig(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml":                    "extends: https://example.com/configs/team.yaml",
+		"https://example.com/configs/team.yaml": "extends: ../shared/base.yaml\nteam: kyma/goat",
+		"https://example.com/shared/base.yaml":  baseConfig,
+	}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma/goat", result.Team)
+	require.Equal(t, "https://kyma-project.io/docs", result.Documentation)
+}
+
+func Test_ParseModuleConfig_ExpandsVariablesInBaseConfig(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "extends: base.yaml",
+		"base.yaml":          "documentation: https://kyma-project.io/${MODULE}",
+	}}
+	vars := variables.New(map[string]string{"MODULE": "sample"}, true)
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{Variables: vars})
+
+	require.NoError(t, err)
+	require.Equal(t, "https://kyma-project.io/sample", result.Documentation)
+}
+
+func Test_ParseModuleConfig_ExpandsModuleNameAndVersionVariables(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": `
+name: kyma-project.io/module/sample
+version: 1.2.3
+documentation: https://kyma-project.io/${MODULE_NAME}/${MODULE_VERSION}
+`,
+	}}
+
+	tests := []struct {
+		name     string
+		version  string
+		expected string
+	}{
+		{name: "version from config", expected: "https://kyma-project.io/kyma-project.io/module/sample/1.2.3"},
+		{name: "version option", version: "2.0.0", expected: "https://kyma-project.io/kyma-project.io/module/sample/2.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars := variables.New(nil, true).WithLookupEnv(func(_ string) (string, bool) { return "", false })
+
+			result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs,
+				&fileResolverStub{fileSystem: fs}, moduleconfigreader.Options{Variables: vars, Version: tt.version})
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result.Documentation)
+		})
+	}
+}
+
+func Test_ParseModuleConfig_ResolvesBaseConfigOnce_WhenExpandingVariables(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml":            "extends: https://example.com/base.yaml\nname: kyma-project.io/module/sample",
+		"https://example.com/base.yaml": "documentation: https://kyma-project.io/${MODULE_NAME}",
+	}}
+	resolver := &fileResolverStub{fileSystem: fs}
+	vars := variables.New(nil, true).WithLookupEnv(func(_ string) (string, bool) { return "", false })
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, resolver,
+		moduleconfigreader.Options{Variables: vars})
+
+	require.NoError(t, err)
+	require.Equal(t, "https://kyma-project.io/kyma-project.io/module/sample", result.Documentation)
+	require.Equal(t, 1, resolver.resolveCallCount)
+}
+
+func Test_ParseModuleConfig_ReturnsError_WhenExtendsIsCyclic(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "extends: base.yaml",
+		"base.yaml":          "extends: ./module-config.yaml",
+	}}
+
+	_, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{})
+
+	require.ErrorIs(t, err, moduleconfigreader.ErrExtendsCycle)
+}
+
+func Test_ParseModuleConfig_ReturnsError_WhenExtendsIsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		extends string
+	}{
+		{name: "not a string", extends: "extends: [base.yaml]"},
+		{name: "empty", extends: "extends: ''"},
+		{name: "http URL", extends: "extends: http://example.com/base.yaml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": tt.extends}}
+
+			_, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs,
+				&fileResolverStub{fileSystem: fs}, moduleconfigreader.Options{})
+
+			require.ErrorIs(t, err, moduleconfigreader.ErrInvalidExtends)
+		})
+	}
+}
+
+func Test_ParseModuleConfig_ReturnsError_WhenBaseConfigCannotBeResolved(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": "extends: missing.yaml"}}
+
+	_, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{})
+
+	require.ErrorIs(t, err, errFileNotFound)
+	require.ErrorContains(t, err, `failed to resolve base config "missing.yaml"`)
+}
+
+func Test_GetEffectiveModuleConfig_ReturnsMergedConfig(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "extends: base.yaml\nname: kyma-project.io/module/sample\nteam: kyma/goat",
+		"base.yaml":          "team: kyma/jellyfish\nbeta: true",
+	}}
+	svc, _ := moduleconfigreader.NewService(fs, &fileResolverStub{fileSystem: fs})
+
+	result, err := svc.GetEffectiveModuleConfig("module-config.yaml", moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, "team: kyma/goat\nbeta: true\nname: kyma-project.io/module/sample\n", result)
+}
+
+var errFileNotFound = errors.New("file not found")
+
+type configFileSystemStub struct {
+	files map[string]string
+}
+
+func (s *configFileSystemStub) ReadFile(filePath string) ([]byte, error) {
+	content, ok := s.files[filePath]
+	if !ok {
+		return nil, errFileNotFound
+	}
+	return []byte(content), nil
+}
+
+// fileResolverStub resolves URLs to the URL string itself and local files relative to the base path.
+type fileResolverStub struct {
+	fileSystem       *configFileSystemStub
+	resolveCallCount int
+}
+
+func (s *fileResolverStub) Resolve(fileRef contentprovider.UrlOrLocalFile, basePath string) (string, error) {
+	s.resolveCallCount++
+	resolved := fileRef.String()
+	if !fileRef.IsURL() {
+		resolved = path.Join(basePath, fileRef.String())
+	}
+	if s.fileSystem != nil {
+		if _, ok := s.fileSystem.files[resolved]; !ok {
+			return "", errFileNotFound
+		}
+	}
+	return resolved, nil
+}
+
+func (*fileResolverStub) CleanupTempFiles() []error {
+	return nil
+}
@@ -0,0 +1,55 @@
+package moduleconfigreader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidJSON = errors.New("invalid JSON")
+
+// isJSON returns true if the config is a JSON document, i.e., its first non-whitespace character opens a JSON object.
+func isJSON(data []byte) bool {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// validateJSON checks that the config is a valid JSON document. JSON is valid YAML, so a JSON config is parsed like a
+// YAML config afterwards, but YAML also accepts documents that are not valid JSON, e.g. with trailing commas.
+func validateJSON(data []byte) error {
+	var document any
+	err := json.Unmarshal(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), &document)
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line := bytes.Count(data[:min(int(syntaxErr.Offset), len(data))], []byte("\n")) + 1
+		return fmt.Errorf("line %d: %w: %w", line, ErrInvalidJSON, err)
+	}
+	return fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+}
+
+// unescapeSlashes replaces the JSON escape sequence \/ that is not supported by the YAML parser with a plain slash.
+// Backslashes only occur in strings of a valid JSON document, and line numbers are preserved.
+func unescapeSlashes(data []byte) []byte {
+	if !bytes.Contains(data, []byte(`\/`)) {
+		return data
+	}
+
+	result := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' || i+1 == len(data) {
+			result = append(result, data[i])
+			continue
+		}
+		if data[i+1] != '/' {
+			result = append(result, data[i])
+		}
+		result = append(result, data[i+1])
+		i++
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+package moduleconfigreader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/variables"
+)
+
+const jsonConfig = `{
+	"name": "kyma-project.io\/module\/sample",
+	"version": "1.0.0",
+	"manifest": "https://example.com/manifest.yaml",
+	"defaultCR": "default-cr.yaml",
+	"icons": [
+		{"name": "module-icon", "link": "https://example.com/icon.svg"}
+	],
+	"resources": {"rawManifest": "https://example.com/manifest.yaml"},
+	"labels": {"label1": "value1"},
+	"manager": {"name": "manager", "group": "apps", "version": "v1", "kind": "Deployment"},
+	"securityScanEnabled": false,
+	"requiresDowntime": true,
+	"internal": null
+}`
+
+func Test_ParseModuleConfig_ParsesJSON(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{"module-config.json": jsonConfig}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.json", fs, &fileResolverStub{},
+		moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma-project.io/module/sample", result.Name)
+	require.Equal(t, "1.0.0", result.Version)
+	require.True(t, result.Manifest.IsURL())
+	require.Equal(t, "https://example.com/manifest.yaml", result.Manifest.String())
+	require.False(t, result.DefaultCR.IsURL())
+	require.Equal(t, "default-cr.yaml", result.DefaultCR.String())
+	require.Equal(t, contentprovider.Icons{"module-icon": "https://example.com/icon.svg"}, result.Icons)
+	require.Equal(t, contentprovider.Resources{"rawManifest": "https://example.com/manifest.yaml"}, result.Resources)
+	require.Equal(t, map[string]string{"label1": "value1"}, result.Labels)
+	require.Equal(t, "Deployment", result.Manager.Kind)
+	require.NotNil(t, result.SecurityScanEnabled)
+	require.False(t, *result.SecurityScanEnabled)
+	require.True(t, result.RequiresDowntime)
+	require.False(t, result.Internal)
+}
+
+func Test_ParseModuleConfig_UnescapesJSONStrings(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.json": `{"documentation": "https:\/\/example.com\/a\\\/b\\\\\/c\u0021\t"}`,
+	}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.json", fs, &fileResolverStub{},
+		moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/a\\/b\\\\/c!\t", result.Documentation)
+}
+
+func Test_ParseModuleConfig_MergesJSONAndYAMLConfigs(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.json": `{"extends": "base.yaml", "labels": {"label2": "value2"}}`,
+		"base.yaml":          "labels:\n  label1: value1",
+	}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.json", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"label1": "value1", "label2": "value2"}, result.Labels)
+}
+
+func Test_ParseModuleConfig_ReportsLineOfUnknownJSONField(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.json": "{\n  \"name\": \"sample\",\n  \"requireDowntime\": true\n}",
+	}}
+
+	_, err := moduleconfigreader.ParseModuleConfig("module-config.json", fs, &fileResolverStub{},
+		moduleconfigreader.Options{})
+
+	require.ErrorIs(t, err, moduleconfigreader.ErrUnknownField)
+	require.ErrorContains(t, err, `line 3: field "requireDowntime", did you mean "requiresDowntime"?`)
+}
+
+func Test_ParseModuleConfig_ReturnsError_WhenJSONIsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{name: "trailing comma", config: `{"name": "sample",}`},
+		{name: "unterminated object", config: `{"name": "sample"`},
+		{name: "trailing data", config: `{"name": "sample"} {}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &configFileSystemStub{files: map[string]string{"module-config.json": tt.config}}
+
+			_, err := moduleconfigreader.ParseModuleConfig("module-config.json", fs, &fileResolverStub{},
+				moduleconfigreader.Options{})
+
+			require.ErrorIs(t, err, moduleconfigreader.ErrInvalidJSON)
+		})
+	}
+}
+
+func Test_ParseAndValidateModuleConfig_ReadsFromStdin(t *testing.T) {
+	svc, _ := moduleconfigreader.NewService(&configFileSystemStub{}, &fileResolverStub{})
+	svc = svc.WithStdin(strings.NewReader(`{
+		"name": "kyma-project.io/module/sample",
+		"version": "1.0.0",
+		"manifest": "https://example.com/manifest.yaml",
+		"repository": "https://example.com/repository",
+		"documentation": "https://example.com/documentation",
+		"team": "kyma/goat",
+		"icons": {"module-icon": "https://example.com/icon.svg"}
+	}`))
+
+	result, err := svc.ParseAndValidateModuleConfig(moduleconfigreader.StdinConfigFile, moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma-project.io/module/sample", result.Name)
+}
+
+func Test_ParseAndValidateModuleConfig_ReadsFromStdin_WhenExpandingVariables(t *testing.T) {
+	svc, _ := moduleconfigreader.NewService(&configFileSystemStub{}, &fileResolverStub{})
+	svc = svc.WithStdin(strings.NewReader(`{
+		"name": "kyma-project.io/module/sample",
+		"version": "1.0.0",
+		"manifest": "https://example.com/manifest.yaml",
+		"repository": "https://example.com/repository",
+		"documentation": "https://example.com/${TOPIC}",
+		"team": "kyma/goat",
+		"icons": {"module-icon": "https://example.com/icon.svg"}
+	}`))
+	vars := variables.New(map[string]string{"TOPIC": "documentation"}, true)
+
+	result, err := svc.ParseAndValidateModuleConfig(moduleconfigreader.StdinConfigFile,
+		moduleconfigreader.Options{Variables: vars})
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma-project.io/module/sample", result.Name)
+	require.Equal(t, "https://example.com/documentation", result.Documentation)
+}
@@ -0,0 +1,178 @@
+package moduleconfigreader
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+)
+
+var ErrUnknownField = errors.New("unknown field")
+
+//nolint:gochecknoglobals // read-only reflection types
+var (
+	unmarshalerType         = reflect.TypeFor[yaml.Unmarshaler]()
+	obsoleteUnmarshalerType = reflect.TypeFor[interface {
+		UnmarshalYAML(unmarshal func(any) error) error
+	}]()
+)
+
+// deprecatedFields maps the attributes that are no longer part of the module config to the reason why they are
+// ignored. They are reported as a warning instead of being rejected as unknown fields.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var deprecatedFields = map[string]string{
+	"channel": "the channels of a module are configured in its ModuleReleaseMeta",
+}
+
+// removeDeprecatedFields removes the deprecated attributes from the config and returns a warning for each of them.
+func removeDeprecatedFields(config *yaml.Node) []string {
+	var warnings []string
+	for i := 0; i+1 < len(config.Content); {
+		key := config.Content[i]
+		reason, deprecated := deprecatedFields[key.Value]
+		if !deprecated {
+			i += 2
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("line %d: field %q is deprecated and ignored, %s", key.Line,
+			key.Value, reason))
+		config.Content = slices.Delete(config.Content, i, i+2)
+	}
+	return warnings
+}
+
+// checkKnownFields returns an error listing all attributes of the config that are not part of the module config,
+// together with a suggestion for the closest known attribute name.
+func checkKnownFields(config *yaml.Node) error {
+	var unknown []string
+	collectUnknownFields(config, reflect.TypeFor[contentprovider.ModuleConfig](), "", &unknown)
+	if len(unknown) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s; use --allow-unknown-fields to ignore them", ErrUnknownField,
+		strings.Join(unknown, "; "))
+}
+
+func collectUnknownFields(node *yaml.Node, typ reflect.Type, path string, unknown *[]string) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if hasCustomUnmarshaler(typ) {
+		return
+	}
+
+	switch {
+	case node.Kind == yaml.SequenceNode && typ.Kind() == reflect.Slice:
+		for i, item := range node.Content {
+			collectUnknownFields(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	case node.Kind == yaml.MappingNode && typ.Kind() == reflect.Struct:
+		fields := structFields(typ)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			fieldPath := joinPath(path, key.Value)
+
+			fieldType, known := fields[key.Value]
+			if !known {
+				*unknown = append(*unknown, unknownFieldMessage(key, fieldPath, fields))
+				continue
+			}
+			collectUnknownFields(value, fieldType, fieldPath, unknown)
+		}
+	}
+}
+
+func hasCustomUnmarshaler(typ reflect.Type) bool {
+	pointerType := reflect.PointerTo(typ)
+	return pointerType.Implements(obsoleteUnmarshalerType) || pointerType.Implements(unmarshalerType)
+}
+
+// structFields returns the YAML attribute names of the struct fields, following the naming rules of yaml.v3.
+func structFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for field := range typ.Fields() {
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if strings.Contains(options, "inline") {
+			inlineType := field.Type
+			for inlineType.Kind() == reflect.Pointer {
+				inlineType = inlineType.Elem()
+			}
+			for inlineName, inlineFieldType := range structFields(inlineType) {
+				fields[inlineName] = inlineFieldType
+			}
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func unknownFieldMessage(key *yaml.Node, fieldPath string, fields map[string]reflect.Type) string {
+	msg := fmt.Sprintf("line %d: field %q", key.Line, fieldPath)
+	if suggestion := closestFieldName(key.Value, fields); suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return msg
+}
+
+// closestFieldName returns the known field name with the smallest edit distance to name, if it is close enough to
+// be a likely typo.
+func closestFieldName(name string, fields map[string]reflect.Type) string {
+	closest := ""
+	closestDistance := len(name)/2 + 1
+	for field := range fields {
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(field))
+		if distance < closestDistance || (distance == closestDistance && closest != "" && field < closest) {
+			closest = field
+			closestDistance = distance
+		}
+	}
+	return closest
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,147 @@
+package moduleconfigreader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/common/types/component"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+func Test_ParseModuleConfig_ReturnsError_WhenFieldIsUnknown(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        string
+		expectedError string
+	}{
+		{
+			name:          "typo in top-level field",
+			config:        "name: kyma-project.io/module/sample\nrequireDowntime: true",
+			expectedError: `line 2: field "requireDowntime", did you mean "requiresDowntime"?`,
+		},
+		{
+			name:          "wrong case",
+			config:        "defaultCr: default-cr.yaml",
+			expectedError: `line 1: field "defaultCr", did you mean "defaultCR"?`,
+		},
+		{
+			name:          "typo in nested field",
+			config:        "manager:\n  name: manager\n  kinds: Deployment",
+			expectedError: `line 3: field "manager.kinds", did you mean "kind"?`,
+		},
+		{
+			name:          "typo in list item",
+			config:        "associatedResources:\n- group: apps\n  versions: v1\n  kind: Deployment",
+			expectedError: `line 3: field "associatedResources[0].versions", did you mean "version"?`,
+		},
+		{
+			name:          "typo in label",
+			config:        "componentLabels:\n- name: example.com/owner\n  valeu: jellyfish",
+			expectedError: `line 3: field "componentLabels[0].valeu", did you mean "value"?`,
+		},
+		{
+			name:          "no close match",
+			config:        "completelyUnrelated: true",
+			expectedError: `unknown field: line 1: field "completelyUnrelated"; use --allow-unknown-fields`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": tt.config}}
+
+			_, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+				moduleconfigreader.Options{})
+
+			require.ErrorIs(t, err, moduleconfigreader.ErrUnknownField)
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_ParseModuleConfig_ReportsAllUnknownFields(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "extends: base.yaml\nversion: 1.0.0\nbetta: true",
+		"base.yaml":          "teams: kyma/goat",
+	}}
+
+	_, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{})
+
+	require.ErrorIs(t, err, moduleconfigreader.ErrUnknownField)
+	require.ErrorContains(t, err, `field "teams", did you mean "team"?`)
+	require.ErrorContains(t, err, `field "betta", did you mean "beta"?`)
+}
+
+func Test_ParseModuleConfig_AcceptsKnownFields(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": `
+name: kyma-project.io/module/sample
+icons:
+- name: module-icon
+  link: https://example.com/icon.svg
+resources:
+  rawManifest: https://example.com/manifest.yaml
+manager:
+  name: manager
+  group: apps
+  version: v1
+  kind: Deployment
+  containerName: manager
+primaryCRD:
+  group: operator.kyma-project.io
+  kind: Sample
+labels:
+  any-label: value
+componentLabels:
+- name: example.com/owner
+  value:
+    team: jellyfish
+    channels: [regular, fast]
+  version: v2
+resourceLabels:
+  raw-manifest:
+  - name: example.com/purpose
+    value: deploy
+`}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+		moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, []component.Label{{
+		Name:    "example.com/owner",
+		Value:   map[string]any{"team": "jellyfish", "channels": []any{"regular", "fast"}},
+		Version: "v2",
+	}}, result.ComponentLabels)
+	require.Equal(t, map[string][]component.Label{
+		"raw-manifest": {{Name: "example.com/purpose", Value: "deploy"}},
+	}, result.ResourceLabels)
+}
+
+func Test_ParseModuleConfig_IgnoresUnknownFields_WhenAllowed(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "name: kyma-project.io/module/sample\nfutureField: true",
+	}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+		moduleconfigreader.Options{AllowUnknownFields: true})
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma-project.io/module/sample", result.Name)
+}
+
+func Test_ParseModuleConfig_IgnoresDeprecatedFieldsWithWarning(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "name: kyma-project.io/module/sample\nchannel: regular",
+	}}
+	out := &strings.Builder{}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+		moduleconfigreader.Options{Out: iotools.NewDefaultOut(out)})
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma-project.io/module/sample", result.Name)
+	require.Contains(t, out.String(), `WARNING: line 2: field "channel" is deprecated and ignored`)
+}
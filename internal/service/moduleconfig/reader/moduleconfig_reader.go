@@ -2,37 +2,107 @@ package moduleconfigreader
 
 import (
 	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kyma-project/modulectl/internal/common"
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/common/validation"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/variables"
+	iotools "github.com/kyma-project/modulectl/tools/io"
 )
 
 type FileSystem interface {
 	ReadFile(path string) ([]byte, error)
 }
 
+type FileResolver interface {
+	Resolve(fileRef contentprovider.UrlOrLocalFile, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
+// StdinConfigFile is the module config file path that refers to the standard input.
+const StdinConfigFile = "-"
+
+// Options control how the module config file is read.
+type Options struct {
+	// Variables are expanded in the module config and its base configs if not nil.
+	Variables *variables.Variables
+	// Profile is the name of the profile whose overlay is applied on top of the module config.
+	Profile string
+	// AllowUnknownFields disables the rejection of attributes that are not part of the module config.
+	AllowUnknownFields bool
+	// Version overrides the version of the module config if not empty.
+	Version string
+	// Out receives warnings about the module config, e.g. about deprecated attributes, if not nil.
+	Out iotools.Out
+}
+
 type Service struct {
-	fileSystem FileSystem
+	fileSystem   FileSystem
+	fileResolver FileResolver
+	stdin        io.Reader
 }
 
-func NewService(fileSystem FileSystem) (*Service, error) {
+func NewService(fileSystem FileSystem, fileResolver FileResolver) (*Service, error) {
 	if fileSystem == nil {
 		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
+	if fileResolver == nil {
+		return nil, fmt.Errorf("fileResolver must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
 	return &Service{
-		fileSystem: fileSystem,
+		fileSystem:   fileSystem,
+		fileResolver: fileResolver,
+		stdin:        os.Stdin,
 	}, nil
 }
 
+// WithStdin sets the reader used when the module config file is StdinConfigFile.
+func (s *Service) WithStdin(stdin io.Reader) *Service {
+	s.stdin = stdin
+	return s
+}
+
+// CleanupTempFiles removes the temporary files of remote module configs and base configs.
+func (s *Service) CleanupTempFiles() []error {
+	return s.fileResolver.CleanupTempFiles()
+}
+
+// GetEffectiveModuleConfig returns the module config in YAML format after all base configs referenced by the extends
+// attribute have been merged and the overlay of the profile has been applied. The result is not validated.
+func (s *Service) GetEffectiveModuleConfig(moduleConfigFile string, opts Options) (string, error) {
+	config, err := loadEffectiveConfig(moduleConfigFile, s.configFileSystem(), s.fileResolver, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to load module config file: %w", err)
+	}
+
+	buffer := &strings.Builder{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return "", fmt.Errorf("failed to marshal effective module config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to marshal effective module config: %w", err)
+	}
+	return buffer.String(), nil
+}
+
 func (s *Service) ParseAndValidateModuleConfig(moduleConfigFile string,
+	opts Options,
 ) (*contentprovider.ModuleConfig, error) {
-	moduleConfig, err := ParseModuleConfig(moduleConfigFile, s.fileSystem)
+	moduleConfig, err := ParseModuleConfig(moduleConfigFile, s.configFileSystem(), s.fileResolver, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse module config file: %w", err)
 	}
@@ -44,6 +114,29 @@ func (s *Service) ParseAndValidateModuleConfig(moduleConfigFile string,
 	return moduleConfig, nil
 }
 
+func (s *Service) configFileSystem() FileSystem {
+	return &stdinFileSystem{FileSystem: s.fileSystem, stdin: s.stdin}
+}
+
+// stdinFileSystem reads the StdinConfigFile from the standard input and all other files from the file system.
+type stdinFileSystem struct {
+	FileSystem
+
+	stdin io.Reader
+}
+
+func (f *stdinFileSystem) ReadFile(path string) ([]byte, error) {
+	if path != StdinConfigFile {
+		return f.FileSystem.ReadFile(path)
+	}
+
+	data, err := io.ReadAll(f.stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from stdin: %w", err)
+	}
+	return data, nil
+}
+
 //nolint:cyclop,funlen // validation function with many checks is acceptable
 func ValidateModuleConfig(moduleConfig *contentprovider.ModuleConfig) error {
 	if err := validation.ValidateModuleName(moduleConfig.Name); err != nil {
@@ -55,10 +148,10 @@ func ValidateModuleConfig(moduleConfig *contentprovider.ModuleConfig) error {
 	}
 
 	if moduleConfig.Manifest.IsURL() {
-		if moduleConfig.Manifest.URL().Scheme != "https" {
+		if !moduleConfig.Manifest.IsRemote() {
 			return fmt.Errorf(
 				"failed to validate manifest: %w", fmt.Errorf(
-					"'%s' is not using https scheme: %w",
+					"'%s' is not using https, oci or git+https scheme: %w",
 					moduleConfig.Manifest.String(),
 					commonerrors.ErrInvalidOption,
 				),
@@ -74,17 +167,30 @@ func ValidateModuleConfig(moduleConfig *contentprovider.ModuleConfig) error {
 		}
 	}
 
-	if err := validation.ValidateIsValidHTTPSURL(moduleConfig.Repository); err != nil {
-		return fmt.Errorf("failed to validate repository: %w", err)
+	if moduleConfig.ManifestSHA256 != "" {
+		if err := validation.ValidateSHA256(moduleConfig.ManifestSHA256); err != nil {
+			return fmt.Errorf("failed to validate manifest checksum: %w", err)
+		}
+	}
+
+	// An empty repository is inferred from the git remote of the module sources.
+	if moduleConfig.Repository != "" {
+		if err := validation.ValidateIsValidHTTPSURL(moduleConfig.Repository); err != nil {
+			return fmt.Errorf("failed to validate repository: %w", err)
+		}
 	}
 
-	// Team is required only when security scan is enabled (default: true)
+	// Team is required only when security scan is enabled (default: true) and no responsibles are configured
 	securityScanEnabled := moduleConfig.SecurityScanEnabled == nil || *moduleConfig.SecurityScanEnabled
-	if securityScanEnabled && moduleConfig.Team == "" {
+	if securityScanEnabled && moduleConfig.Team == "" && len(moduleConfig.Responsibles) == 0 {
 		return fmt.Errorf("failed to validate team: must not be empty when security scan is enabled: %w",
 			commonerrors.ErrInvalidOption)
 	}
 
+	if err := ValidateResponsibles(moduleConfig.Responsibles); err != nil {
+		return fmt.Errorf("failed to validate responsibles: %w", err)
+	}
+
 	if err := validation.ValidateIsValidHTTPSURL(moduleConfig.Documentation); err != nil {
 		return fmt.Errorf("failed to validate documentation: %w", err)
 	}
@@ -103,11 +209,11 @@ func ValidateModuleConfig(moduleConfig *contentprovider.ModuleConfig) error {
 	}
 
 	if moduleConfig.DefaultCR.IsURL() {
-		if moduleConfig.DefaultCR.URL().Scheme != "https" {
+		if !moduleConfig.DefaultCR.IsRemote() {
 			return fmt.Errorf(
 				"failed to validate default CR: %w",
 				fmt.Errorf(
-					"'%s' is not using https scheme: %w",
+					"'%s' is not using https, oci or git+https scheme: %w",
 					moduleConfig.DefaultCR.String(),
 					commonerrors.ErrInvalidOption,
 				),
@@ -120,6 +226,16 @@ func ValidateModuleConfig(moduleConfig *contentprovider.ModuleConfig) error {
 		}
 	}
 
+	if moduleConfig.DefaultCRSHA256 != "" {
+		if moduleConfig.DefaultCR.IsEmpty() {
+			return fmt.Errorf("failed to validate default CR checksum: default CR must be set: %w",
+				commonerrors.ErrInvalidOption)
+		}
+		if err := validation.ValidateSHA256(moduleConfig.DefaultCRSHA256); err != nil {
+			return fmt.Errorf("failed to validate default CR checksum: %w", err)
+		}
+	}
+
 	if err := ValidateAssociatedResources(moduleConfig.AssociatedResources); err != nil {
 		return fmt.Errorf("failed to validate associated resources: %w", err)
 	}
@@ -128,6 +244,179 @@ func ValidateModuleConfig(moduleConfig *contentprovider.ModuleConfig) error {
 		return fmt.Errorf("failed to validate manager: %w", err)
 	}
 
+	if err := ValidatePrimaryCRD(moduleConfig.PrimaryCRD); err != nil {
+		return fmt.Errorf("failed to validate primary CRD: %w", err)
+	}
+
+	if err := ValidateLabels(moduleConfig.ComponentLabels); err != nil {
+		return fmt.Errorf("failed to validate component labels: %w", err)
+	}
+
+	if err := validateLabelsByName(moduleConfig.ResourceLabels); err != nil {
+		return fmt.Errorf("failed to validate resource labels: %w", err)
+	}
+
+	if err := validateLabelsByName(moduleConfig.SourceLabels); err != nil {
+		return fmt.Errorf("failed to validate source labels: %w", err)
+	}
+
+	if err := ValidateComponentResources(moduleConfig.ComponentResources); err != nil {
+		return fmt.Errorf("failed to validate component resources: %w", err)
+	}
+
+	if err := ValidateComponentReferences(moduleConfig.ComponentReferences, moduleConfig.Name); err != nil {
+		return fmt.Errorf("failed to validate component references: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateLabels validates user-defined OCM labels. Their names must be qualified with a DNS domain, must not be
+// reserved for labels set by modulectl and must be unique.
+func ValidateLabels(labels []component.Label) error {
+	names := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if err := validation.ValidateLabelName(label.Name); err != nil {
+			return fmt.Errorf("label is invalid: %w", err)
+		}
+
+		if names[label.Name] {
+			return fmt.Errorf("label '%s' must not be defined multiple times: %w", label.Name,
+				commonerrors.ErrInvalidOption)
+		}
+		names[label.Name] = true
+
+		if label.Value == nil {
+			return fmt.Errorf("value of label '%s' must not be empty: %w", label.Name, commonerrors.ErrInvalidOption)
+		}
+
+		if err := validation.ValidateLabelVersion(label.Version); err != nil {
+			return fmt.Errorf("label '%s' is invalid: %w", label.Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateResponsibles validates the responsibles of the module, which must not be defined multiple times.
+func ValidateResponsibles(responsibles []contentprovider.Responsible) error {
+	identities := make(map[contentprovider.Responsible]bool, len(responsibles))
+	for _, responsible := range responsibles {
+		if err := validation.ValidateResponsible(responsible.Type, responsible.Name,
+			responsible.GitHubHostname); err != nil {
+			return fmt.Errorf("responsible is invalid: %w", err)
+		}
+
+		// GitHub responsibles without hostname default to the SAP GitHub hostname
+		if responsible.Type != common.ResponsibleTypeEmail && responsible.GitHubHostname == "" {
+			responsible.GitHubHostname = common.GitHubHostname
+		}
+		if identities[responsible] {
+			return fmt.Errorf("responsible '%s' of type '%s' must not be defined multiple times: %w", responsible.Name,
+				responsible.Type, commonerrors.ErrInvalidOption)
+		}
+		identities[responsible] = true
+	}
+	return nil
+}
+
+// ValidateComponentResources validates the additional OCM resources of the component. Their names must be unique and
+// must not be used by the resources created by modulectl.
+func ValidateComponentResources(resources []contentprovider.ComponentResource) error {
+	names := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		if err := validation.ValidateResourceName(resource.Name); err != nil {
+			return fmt.Errorf("resource is invalid: %w", err)
+		}
+
+		if names[resource.Name] {
+			return fmt.Errorf("resource '%s' must not be defined multiple times: %w", resource.Name,
+				commonerrors.ErrInvalidOption)
+		}
+		names[resource.Name] = true
+
+		if resource.Type == "" {
+			return fmt.Errorf("type of resource '%s' must not be empty: %w", resource.Name,
+				commonerrors.ErrInvalidOption)
+		}
+
+		if resource.MediaType != "" {
+			if _, _, err := mime.ParseMediaType(resource.MediaType); err != nil {
+				return fmt.Errorf("media type '%s' of resource '%s' is invalid: %w", resource.MediaType,
+					resource.Name, commonerrors.ErrInvalidOption)
+			}
+		}
+
+		if err := validateComponentResourcePath(resource.Path); err != nil {
+			return fmt.Errorf("path of resource '%s' is invalid: %w", resource.Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateComponentReferences validates the references to the OCM components the module component is composed of.
+// Their names must be unique and the module component must not reference itself.
+func ValidateComponentReferences(references []contentprovider.ComponentReference, moduleName string) error {
+	names := make(map[string]bool, len(references))
+	for _, reference := range references {
+		if err := validation.ValidateComponentReferenceName(reference.Name); err != nil {
+			return fmt.Errorf("component reference is invalid: %w", err)
+		}
+
+		if names[reference.Name] {
+			return fmt.Errorf("component reference '%s' must not be defined multiple times: %w", reference.Name,
+				commonerrors.ErrInvalidOption)
+		}
+		names[reference.Name] = true
+
+		if err := validation.ValidateComponentName(reference.ComponentName); err != nil {
+			return fmt.Errorf("component reference '%s' is invalid: %w", reference.Name, err)
+		}
+
+		if reference.ComponentName == moduleName {
+			return fmt.Errorf("component reference '%s' must not reference the module itself: %w", reference.Name,
+				commonerrors.ErrInvalidOption)
+		}
+
+		if err := validation.ValidateComponentVersion(reference.Version); err != nil {
+			return fmt.Errorf("component reference '%s' is invalid: %w", reference.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateComponentResourcePath validates the path of a component resource, which is either a remote URL or a path
+// relative to the module config file that must not point outside the directory of the module config file.
+func validateComponentResourcePath(resourcePath contentprovider.UrlOrLocalFile) error {
+	if resourcePath.IsEmpty() {
+		return fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+	if resourcePath.IsURL() {
+		if !resourcePath.IsRemote() {
+			return fmt.Errorf("'%s' is not using https, oci or git+https scheme: %w", resourcePath.String(),
+				commonerrors.ErrInvalidOption)
+		}
+		return nil
+	}
+	if strings.HasPrefix(resourcePath.String(), "/") {
+		return fmt.Errorf("must not be an absolute path: %w", commonerrors.ErrInvalidOption)
+	}
+	if cleanPath := path.Clean(resourcePath.String()); cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		return fmt.Errorf("'%s' must not point outside the module config directory: %w", resourcePath.String(),
+			commonerrors.ErrInvalidOption)
+	}
+	return nil
+}
+
+func validateLabelsByName(labelsByName map[string][]component.Label) error {
+	for name, labels := range labelsByName {
+		if name == "" {
+			return fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption)
+		}
+
+		if err := ValidateLabels(labels); err != nil {
+			return fmt.Errorf("labels of '%s' are invalid: %w", name, err)
+		}
+	}
 	return nil
 }
 
@@ -162,16 +451,54 @@ func ValidateManager(manager *contentprovider.Manager) error {
 	return nil
 }
 
-func ParseModuleConfig(configFilePath string, fileSystem FileSystem) (*contentprovider.ModuleConfig, error) {
-	moduleConfigData, err := fileSystem.ReadFile(configFilePath)
+func ValidatePrimaryCRD(primaryCRD *metav1.GroupKind) error {
+	if primaryCRD == nil {
+		return nil
+	}
+
+	if primaryCRD.Group == "" {
+		return fmt.Errorf("group must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if primaryCRD.Kind == "" {
+		return fmt.Errorf("kind must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ParseModuleConfig reads and parses the module config file. If variables are set in opts, they are expanded
+// before parsing. Base configs referenced by the extends attribute are resolved with the fileResolver and merged into
+// the module config. If a profile is set in opts, the overlay of that profile is applied on top. Deprecated attributes
+// are ignored with a warning written to the Out of opts. Unless unknown fields are allowed in opts, attributes that
+// are not part of the module config are rejected. A version set in opts overrides the version of the module config.
+func ParseModuleConfig(configFilePath string, fileSystem FileSystem, fileResolver FileResolver,
+	opts Options,
+) (*contentprovider.ModuleConfig, error) {
+	config, err := loadEffectiveConfig(configFilePath, fileSystem, fileResolver, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read module config file: %w", err)
+		return nil, err
+	}
+
+	for _, warning := range removeDeprecatedFields(config) {
+		if opts.Out != nil {
+			opts.Out.Write(fmt.Sprintf("- WARNING: %s\n", warning))
+		}
+	}
+
+	if !opts.AllowUnknownFields {
+		if err := checkKnownFields(config); err != nil {
+			return nil, fmt.Errorf("failed to parse module config file: %w", err)
+		}
 	}
 
 	moduleConfig := &contentprovider.ModuleConfig{}
-	if err := yaml.Unmarshal(moduleConfigData, moduleConfig); err != nil {
+	if err := config.Decode(moduleConfig); err != nil {
 		return nil, fmt.Errorf("failed to parse module config file: %w", err)
 	}
+	if opts.Version != "" {
+		moduleConfig.Version = opts.Version
+	}
 
 	return moduleConfig, nil
 }
@@ -3,6 +3,7 @@ package moduleconfigreader_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
 )
@@ -25,14 +27,16 @@ const (
 )
 
 func Test_ParseModuleConfig_ReturnsError_WhenFileReaderReturnsError(t *testing.T) {
-	result, err := moduleconfigreader.ParseModuleConfig(moduleConfigFile, &fileDoesNotExistStub{})
+	result, err := moduleconfigreader.ParseModuleConfig(moduleConfigFile, &fileDoesNotExistStub{}, nil,
+		moduleconfigreader.Options{})
 
 	require.ErrorIs(t, err, errReadingFile)
 	require.Nil(t, result)
 }
 
 func Test_ParseModuleConfig_Returns_CorrectModuleConfig(t *testing.T) {
-	result, err := moduleconfigreader.ParseModuleConfig(moduleConfigFile, &fileExistsStub{})
+	result, err := moduleconfigreader.ParseModuleConfig(moduleConfigFile, &fileExistsStub{}, nil,
+		moduleconfigreader.Options{})
 
 	require.NoError(t, err)
 	require.Equal(t, "github.com/module-name", result.Name)
@@ -66,7 +70,10 @@ func Test_ParseModuleConfig_Returns_CorrectModuleConfig(t *testing.T) {
 }
 
 func TestNew_CalledWithNilDependencies_ReturnsErr(t *testing.T) {
-	_, err := moduleconfigreader.NewService(nil)
+	_, err := moduleconfigreader.NewService(nil, &fileResolverStub{})
+	require.Error(t, err)
+
+	_, err = moduleconfigreader.NewService(&fileExistsStub{}, nil)
 	require.Error(t, err)
 }
 
@@ -203,7 +210,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				commonerrors.ErrInvalidOption),
 		},
 		{
-			name: "empty repository",
+			name: "empty repository, inferred from the git remote",
 			moduleConfig: &contentprovider.ModuleConfig{
 				Name:          "github.com/module-name",
 				Version:       "0.0.1",
@@ -215,8 +222,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"module-icon": exampleIcon,
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate repository: must not be empty: %w",
-				commonerrors.ErrInvalidOption),
+			expectedError: nil,
 		},
 		{
 			name: "repository is not a URL",
@@ -422,7 +428,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate manifest: 'file://path/to/manifest' is not using https scheme: %w",
+				"failed to validate manifest: 'file://path/to/manifest' is not using https, oci or git+https scheme: %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -441,7 +447,45 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate default CR: 'file://path/to/defaultCR' is not using https scheme: %w",
+				"failed to validate default CR: 'file://path/to/defaultCR' is not using https, oci or git+https scheme: %w",
+				commonerrors.ErrInvalidOption,
+			),
+		},
+		{
+			name: "invalid manifest checksum",
+			moduleConfig: &contentprovider.ModuleConfig{
+				Name:           "github.com/module-name",
+				Version:        "0.0.1",
+				Manifest:       exampleManifest,
+				ManifestSHA256: "not-a-checksum",
+				Repository:     exampleRepository,
+				Team:           exampleTeam,
+				Documentation:  exampleDocumentation,
+				Icons: contentprovider.Icons{
+					"module-icon": exampleIcon,
+				},
+			},
+			expectedError: fmt.Errorf(
+				"failed to validate manifest checksum: 'not-a-checksum' must be a SHA-256 checksum of 64 hex characters: %w",
+				commonerrors.ErrInvalidOption,
+			),
+		},
+		{
+			name: "default CR checksum without default CR",
+			moduleConfig: &contentprovider.ModuleConfig{
+				Name:            "github.com/module-name",
+				Version:         "0.0.1",
+				Manifest:        exampleManifest,
+				DefaultCRSHA256: strings.Repeat("a", 64),
+				Repository:      exampleRepository,
+				Team:            exampleTeam,
+				Documentation:   exampleDocumentation,
+				Icons: contentprovider.Icons{
+					"module-icon": exampleIcon,
+				},
+			},
+			expectedError: fmt.Errorf(
+				"failed to validate default CR checksum: default CR must be set: %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -556,6 +600,328 @@ func Test_ValidateManager(t *testing.T) {
 	}
 }
 
+func Test_ValidatePrimaryCRD(t *testing.T) {
+	tests := []struct {
+		name       string
+		primaryCRD *metav1.GroupKind
+		wantErr    string
+	}{
+		{name: "not configured", primaryCRD: nil},
+		{name: "valid", primaryCRD: &metav1.GroupKind{Group: "operator.kyma-project.io", Kind: "Sample"}},
+		{name: "missing group", primaryCRD: &metav1.GroupKind{Kind: "Sample"}, wantErr: "group must not be empty"},
+		{
+			name:       "missing kind",
+			primaryCRD: &metav1.GroupKind{Group: "operator.kyma-project.io"},
+			wantErr:    "kind must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := moduleconfigreader.ValidatePrimaryCRD(tt.primaryCRD)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_ValidateLabels(t *testing.T) {
+	tests := []struct {
+		name          string
+		labels        []component.Label
+		expectedError string
+	}{
+		{
+			name:   "pass on empty labels",
+			labels: []component.Label{},
+		},
+		{
+			name: "pass when all labels are valid",
+			labels: []component.Label{
+				{Name: "example.com/owner", Value: map[string]any{"team": "jellyfish"}},
+				{Name: "example.com/tier", Value: 1, Version: "v2"},
+			},
+		},
+		{
+			name:          "fail when name is not qualified",
+			labels:        []component.Label{{Name: "owner", Value: "jellyfish"}},
+			expectedError: "name 'owner' must be qualified with a DNS domain",
+		},
+		{
+			name:          "fail when name is reserved",
+			labels:        []component.Label{{Name: "kyma-project.io/dirty", Value: "false"}},
+			expectedError: "name 'kyma-project.io/dirty' is reserved for labels set by modulectl",
+		},
+		{
+			name: "fail when name is duplicated",
+			labels: []component.Label{
+				{Name: "example.com/owner", Value: "jellyfish"},
+				{Name: "example.com/owner", Value: "goat"},
+			},
+			expectedError: "label 'example.com/owner' must not be defined multiple times",
+		},
+		{
+			name:          "fail when value is empty",
+			labels:        []component.Label{{Name: "example.com/owner"}},
+			expectedError: "value of label 'example.com/owner' must not be empty",
+		},
+		{
+			name:          "fail when version is invalid",
+			labels:        []component.Label{{Name: "example.com/owner", Value: "jellyfish", Version: "1.0"}},
+			expectedError: "version '1.0' must have the format 'v<number>'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := moduleconfigreader.ValidateLabels(test.labels)
+			if test.expectedError != "" {
+				require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+				require.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_ValidateComponentResources(t *testing.T) {
+	tests := []struct {
+		name          string
+		resources     []contentprovider.ComponentResource
+		expectedError string
+	}{
+		{
+			name: "pass when all resources are valid",
+			resources: []contentprovider.ComponentResource{
+				{Name: "docs", Type: "directoryTree", Path: contentprovider.MustUrlOrLocalFile("docs")},
+				{
+					Name:      "dashboard",
+					Type:      "grafanaDashboard",
+					MediaType: "application/json",
+					Path:      contentprovider.MustUrlOrLocalFile("https://example.com/dashboard.json"),
+				},
+			},
+		},
+		{
+			name: "fail when name is reserved",
+			resources: []contentprovider.ComponentResource{
+				{Name: "raw-manifest", Type: "PlainText", Path: contentprovider.MustUrlOrLocalFile("manifest.yaml")},
+			},
+			expectedError: "name 'raw-manifest' is reserved for resources created by modulectl",
+		},
+		{
+			name: "fail when name is duplicated",
+			resources: []contentprovider.ComponentResource{
+				{Name: "docs", Type: "directoryTree", Path: contentprovider.MustUrlOrLocalFile("docs")},
+				{Name: "docs", Type: "PlainText", Path: contentprovider.MustUrlOrLocalFile("README.md")},
+			},
+			expectedError: "resource 'docs' must not be defined multiple times",
+		},
+		{
+			name: "fail when type is empty",
+			resources: []contentprovider.ComponentResource{
+				{Name: "docs", Path: contentprovider.MustUrlOrLocalFile("docs")},
+			},
+			expectedError: "type of resource 'docs' must not be empty",
+		},
+		{
+			name: "fail when media type is invalid",
+			resources: []contentprovider.ComponentResource{
+				{Name: "docs", Type: "PlainText", MediaType: "text/", Path: contentprovider.MustUrlOrLocalFile("a.md")},
+			},
+			expectedError: "media type 'text/' of resource 'docs' is invalid",
+		},
+		{
+			name: "fail when path is empty",
+			resources: []contentprovider.ComponentResource{
+				{Name: "docs", Type: "directoryTree"},
+			},
+			expectedError: "path of resource 'docs' is invalid: must not be empty",
+		},
+		{
+			name: "fail when path is absolute",
+			resources: []contentprovider.ComponentResource{
+				{Name: "docs", Type: "directoryTree", Path: contentprovider.MustUrlOrLocalFile("/docs")},
+			},
+			expectedError: "path of resource 'docs' is invalid: must not be an absolute path",
+		},
+		{
+			name: "fail when path points outside the module config directory",
+			resources: []contentprovider.ComponentResource{
+				{Name: "docs", Type: "directoryTree", Path: contentprovider.MustUrlOrLocalFile("docs/../../shared")},
+			},
+			expectedError: "path of resource 'docs' is invalid: 'docs/../../shared' must not point outside",
+		},
+		{
+			name: "pass when path points to the module config directory",
+			resources: []contentprovider.ComponentResource{
+				{Name: "docs", Type: "directoryTree", Path: contentprovider.MustUrlOrLocalFile(".")},
+			},
+		},
+		{
+			name: "fail when URL is not remote",
+			resources: []contentprovider.ComponentResource{
+				{
+					Name: "dashboard",
+					Type: "grafanaDashboard",
+					Path: contentprovider.MustUrlOrLocalFile("http://example.com/dashboard.json"),
+				},
+			},
+			expectedError: "is not using https, oci or git+https scheme",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := moduleconfigreader.ValidateComponentResources(test.resources)
+			if test.expectedError != "" {
+				require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+				require.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_ValidateComponentReferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		references    []contentprovider.ComponentReference
+		expectedError string
+	}{
+		{
+			name: "pass when all references are valid",
+			references: []contentprovider.ComponentReference{
+				{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+				{Name: "api-gateway", ComponentName: "kyma-project.io/module/api-gateway", Version: "2.0.0-rc.1"},
+			},
+		},
+		{
+			name: "fail when name is invalid",
+			references: []contentprovider.ComponentReference{
+				{Name: "Istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+			},
+			expectedError: "name 'Istio' must consist of lowercase alphanumeric characters and hyphens",
+		},
+		{
+			name: "fail when name is duplicated",
+			references: []contentprovider.ComponentReference{
+				{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.2.0"},
+				{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "1.3.0"},
+			},
+			expectedError: "component reference 'istio' must not be defined multiple times",
+		},
+		{
+			name: "fail when component name is invalid",
+			references: []contentprovider.ComponentReference{
+				{Name: "istio", ComponentName: "istio", Version: "1.2.0"},
+			},
+			expectedError: "component reference 'istio' is invalid: component name 'istio' must match",
+		},
+		{
+			name: "fail when module references itself",
+			references: []contentprovider.ComponentReference{
+				{Name: "self", ComponentName: "kyma-project.io/module/template-operator", Version: "1.2.0"},
+			},
+			expectedError: "component reference 'self' must not reference the module itself",
+		},
+		{
+			name: "fail when version is not a semantic version",
+			references: []contentprovider.ComponentReference{
+				{Name: "istio", ComponentName: "kyma-project.io/module/istio", Version: "latest"},
+			},
+			expectedError: "component reference 'istio' is invalid: version 'latest' must be a semantic version",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := moduleconfigreader.ValidateComponentReferences(test.references,
+				"kyma-project.io/module/template-operator")
+			if test.expectedError != "" {
+				require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+				require.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_ValidateModuleConfig_AcceptsResponsibles_InsteadOfTeam(t *testing.T) {
+	moduleConfig := expectedReturnedModuleConfig
+	moduleConfig.Team = ""
+	moduleConfig.Responsibles = []contentprovider.Responsible{
+		{Type: "githubTeam", Name: "kyma-project/jellyfish", GitHubHostname: "github.com"},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(&moduleConfig)
+
+	require.NoError(t, err)
+}
+
+func Test_ValidateResponsibles(t *testing.T) {
+	tests := []struct {
+		name          string
+		responsibles  []contentprovider.Responsible
+		expectedError string
+	}{
+		{
+			name: "pass when all responsibles are valid",
+			responsibles: []contentprovider.Responsible{
+				{Type: "githubTeam", Name: "kyma/jellyfish"},
+				{Type: "githubUser", Name: "octocat", GitHubHostname: "github.com"},
+				{Type: "emailAddress", Name: "jellyfish@example.com"},
+			},
+		},
+		{
+			name: "fail when type is unknown",
+			responsibles: []contentprovider.Responsible{
+				{Type: "slackChannel", Name: "jellyfish"},
+			},
+			expectedError: "responsible is invalid: type 'slackChannel' must be one of githubTeam, githubUser or " +
+				"emailAddress",
+		},
+		{
+			name: "fail when responsible is duplicated",
+			responsibles: []contentprovider.Responsible{
+				{Type: "githubTeam", Name: "kyma/jellyfish"},
+				{Type: "githubTeam", Name: "kyma/jellyfish", GitHubHostname: "github.tools.sap"},
+			},
+			expectedError: "responsible 'kyma/jellyfish' of type 'githubTeam' must not be defined multiple times",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := moduleconfigreader.ValidateResponsibles(test.responsibles)
+			if test.expectedError != "" {
+				require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+				require.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_ValidateModuleConfig_ValidatesResourceAndSourceLabels(t *testing.T) {
+	moduleConfig := expectedReturnedModuleConfig
+	moduleConfig.ResourceLabels = map[string][]component.Label{
+		"raw-manifest": {{Name: "example.com/purpose", Value: "deploy"}},
+	}
+	moduleConfig.SourceLabels = map[string][]component.Label{
+		"module-sources": {{Name: "kyma-project.io/git-branch", Value: "main"}},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(&moduleConfig)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.ErrorContains(t, err, "failed to validate source labels: labels of 'module-sources' are invalid")
+}
+
 func Test_ValidateAssociatedResources(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -669,3 +1035,15 @@ var errReadingFile = errors.New("some error reading file")
 func (*fileDoesNotExistStub) ReadFile(_ string) ([]byte, error) {
 	return nil, errReadingFile
 }
+
+func Test_ParseModuleConfig_OverridesVersion(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "name: kyma-project.io/module/sample\nversion: 0.0.0\n",
+	}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{Version: "1.2.3"})
+
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", result.Version)
+}
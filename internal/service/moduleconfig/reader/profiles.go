@@ -0,0 +1,59 @@
+package moduleconfigreader
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const profilesKey = "profiles"
+
+var (
+	ErrUnknownProfile = errors.New("unknown profile")
+	ErrInvalidProfile = errors.New("invalid profile")
+)
+
+// applyProfile removes the profiles section from the config and, if a profile name is given, merges the overlay of
+// that profile on top of the config using the same rules as for base configs.
+func applyProfile(config *yaml.Node, profile string) (*yaml.Node, error) {
+	profiles := removeKey(config, profilesKey)
+	if profile == "" {
+		return config, nil
+	}
+
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%q: module config does not define any profiles: %w", profile, ErrUnknownProfile)
+	}
+
+	index := findKey(profiles, profile)
+	if index < 0 {
+		return nil, fmt.Errorf("%q, available profiles: %s: %w", profile, strings.Join(profileNames(profiles), ", "),
+			ErrUnknownProfile)
+	}
+
+	overlay := profiles.Content[index+1]
+	if overlay.Tag == "!!null" {
+		return config, nil
+	}
+	if overlay.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%q: line %d: must be a map: %w", profile, overlay.Line, ErrInvalidProfile)
+	}
+	for _, key := range []string{extendsKey, profilesKey} {
+		if findKey(overlay, key) >= 0 {
+			return nil, fmt.Errorf("%q: must not contain %s: %w", profile, key, ErrInvalidProfile)
+		}
+	}
+	normalizeNamedLists(overlay)
+
+	return mergeConfig(config, overlay), nil
+}
+
+func profileNames(profiles *yaml.Node) []string {
+	names := make([]string, 0, len(profiles.Content)/2)
+	for i := 0; i+1 < len(profiles.Content); i += 2 {
+		names = append(names, profiles.Content[i].Value)
+	}
+	return names
+}
@@ -0,0 +1,109 @@
+package moduleconfigreader_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+)
+
+const profilesConfig = `
+name: kyma-project.io/module/sample
+manifest: https://example.com/dev/manifest.yaml
+beta: true
+labels:
+  channel: dev
+  shared: value
+profiles:
+  prod:
+    manifest: https://example.com/prod/manifest.yaml
+    beta: null
+    labels:
+      channel: prod
+    icons:
+    - name: module-icon
+      link: https://example.com/icon.svg
+  stage:
+`
+
+func Test_ParseModuleConfig_AppliesProfile(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": profilesConfig}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+		moduleconfigreader.Options{Profile: "prod"})
+
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/prod/manifest.yaml", result.Manifest.String())
+	require.False(t, result.Beta)
+	require.Equal(t, map[string]string{"channel": "prod", "shared": "value"}, result.Labels)
+	require.Equal(t, contentprovider.Icons{"module-icon": "https://example.com/icon.svg"}, result.Icons)
+}
+
+func Test_ParseModuleConfig_IgnoresProfiles_WhenNoProfileSelected(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": profilesConfig}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+		moduleconfigreader.Options{})
+
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/dev/manifest.yaml", result.Manifest.String())
+	require.True(t, result.Beta)
+	require.Equal(t, map[string]string{"channel": "dev", "shared": "value"}, result.Labels)
+}
+
+func Test_ParseModuleConfig_AppliesEmptyProfile(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": profilesConfig}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+		moduleconfigreader.Options{Profile: "stage"})
+
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/dev/manifest.yaml", result.Manifest.String())
+}
+
+func Test_ParseModuleConfig_AppliesProfileOfBaseConfig(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{
+		"module-config.yaml": "extends: base.yaml\nprofiles:\n  prod:\n    internal: true",
+		"base.yaml":          "profiles:\n  prod:\n    beta: true",
+	}}
+
+	result, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{fileSystem: fs},
+		moduleconfigreader.Options{Profile: "prod"})
+
+	require.NoError(t, err)
+	require.True(t, result.Internal)
+	require.True(t, result.Beta)
+}
+
+func Test_ParseModuleConfig_ReturnsError_WhenProfileIsUnknown(t *testing.T) {
+	fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": profilesConfig}}
+
+	_, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+		moduleconfigreader.Options{Profile: "canary"})
+
+	require.ErrorIs(t, err, moduleconfigreader.ErrUnknownProfile)
+	require.ErrorContains(t, err, "available profiles: prod, stage")
+}
+
+func Test_ParseModuleConfig_ReturnsError_WhenProfileIsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{name: "not a map", config: "profiles:\n  prod: true"},
+		{name: "contains extends", config: "profiles:\n  prod:\n    extends: base.yaml"},
+		{name: "contains profiles", config: "profiles:\n  prod:\n    profiles: {}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &configFileSystemStub{files: map[string]string{"module-config.yaml": tt.config}}
+
+			_, err := moduleconfigreader.ParseModuleConfig("module-config.yaml", fs, &fileResolverStub{},
+				moduleconfigreader.Options{Profile: "prod"})
+
+			require.ErrorIs(t, err, moduleconfigreader.ErrInvalidProfile)
+		})
+	}
+}
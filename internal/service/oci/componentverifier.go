@@ -0,0 +1,82 @@
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"github.com/kyma-project/modulectl/tools/download"
+	"github.com/kyma-project/modulectl/tools/httpauth"
+)
+
+// componentDescriptorsPath is the repository prefix OCM stores component versions under in an OCI registry.
+const componentDescriptorsPath = "component-descriptors"
+
+var ErrComponentVersionNotFound = errors.New("component version not found")
+
+// ComponentVerifier verifies that OCM component versions exist in an OCI registry, where they are stored as
+// <registry>/component-descriptors/<component name>:<version>.
+type ComponentVerifier struct {
+	credentials      httpauth.CredentialLookup
+	downloadSettings download.SettingsProvider
+}
+
+func NewComponentVerifier() *ComponentVerifier {
+	return &ComponentVerifier{}
+}
+
+// WithCredentials authenticates against registries with the credentials of the registry host. Registries without
+// credentials fall back to the Docker config.
+func (v *ComponentVerifier) WithCredentials(credentials httpauth.CredentialLookup) *ComponentVerifier {
+	v.credentials = credentials
+	return v
+}
+
+// WithDownloadSettings applies the timeout and the offline mode of the download settings to all verifications.
+func (v *ComponentVerifier) WithDownloadSettings(downloadSettings download.SettingsProvider) *ComponentVerifier {
+	v.downloadSettings = downloadSettings
+	return v
+}
+
+// VerifyComponentVersion returns ErrComponentVersionNotFound if the version of the component does not exist in the
+// registry. The registry is given as host with an optional repository path, without oci:// scheme.
+func (v *ComponentVerifier) VerifyComponentVersion(registry, componentName, version string) error {
+	ref, err := ComponentVersionReference(registry, componentName, version)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel, err := download.ContextFor(v.downloadSettings, ref.String())
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	_, err = remote.Head(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(newKeychain(v.credentials)))
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s:%s does not exist in %s: %w", componentName, version, registry,
+			ErrComponentVersionNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get component version %s: %w", ref, err)
+	}
+	return nil
+}
+
+// ComponentVersionReference returns the OCI reference of the component version in the registry. OCM replaces the
+// build metadata separator '+', which is not allowed in tags, with '.build-'.
+func ComponentVersionReference(registry, componentName, version string) (name.Reference, error) {
+	rawRef := fmt.Sprintf("%s/%s/%s:%s", strings.TrimSuffix(registry, "/"), componentDescriptorsPath,
+		componentName, strings.ReplaceAll(version, "+", ".build-"))
+	ref, err := name.ParseReference(rawRef, name.StrictValidation)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid OCI reference: %w: %w", rawRef, ErrInvalidArtifact, err)
+	}
+	return ref, nil
+}
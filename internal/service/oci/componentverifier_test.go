@@ -0,0 +1,101 @@
+package oci_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/service/oci"
+	"github.com/kyma-project/modulectl/tools/download"
+)
+
+func Test_VerifyComponentVersion_Succeeds_WhenComponentVersionExists(t *testing.T) {
+	host := newRegistry(t)
+	push(t, host+"/prod/component-descriptors/kyma-project.io/module/istio:1.2.0",
+		static.NewLayer([]byte("component: istio"), "application/yaml"))
+
+	err := oci.NewComponentVerifier().VerifyComponentVersion(host+"/prod", "kyma-project.io/module/istio", "1.2.0")
+
+	require.NoError(t, err)
+}
+
+func Test_VerifyComponentVersion_UsesCredentialsOfRegistryHost(t *testing.T) {
+	host := newRegistry(t)
+	push(t, host+"/component-descriptors/kyma-project.io/module/istio:1.2.0",
+		static.NewLayer([]byte("component: istio"), "application/yaml"))
+	credentials := &credentialsStub{}
+
+	err := oci.NewComponentVerifier().WithCredentials(credentials).
+		VerifyComponentVersion(host, "kyma-project.io/module/istio", "1.2.0")
+
+	require.NoError(t, err)
+	assert.Equal(t, host, credentials.host)
+}
+
+func Test_VerifyComponentVersion_ReturnsError_WhenComponentVersionDoesNotExist(t *testing.T) {
+	host := newRegistry(t)
+	push(t, host+"/component-descriptors/kyma-project.io/module/istio:1.2.0",
+		static.NewLayer([]byte("component: istio"), "application/yaml"))
+
+	err := oci.NewComponentVerifier().VerifyComponentVersion(host, "kyma-project.io/module/istio", "1.3.0")
+
+	require.ErrorIs(t, err, oci.ErrComponentVersionNotFound)
+	require.ErrorContains(t, err, "kyma-project.io/module/istio:1.3.0 does not exist in "+host)
+}
+
+func Test_VerifyComponentVersion_ReturnsError_InOfflineMode(t *testing.T) {
+	host := newRegistry(t)
+	push(t, host+"/component-descriptors/kyma-project.io/module/istio:1.2.0",
+		static.NewLayer([]byte("component: istio"), "application/yaml"))
+	downloader := download.NewDownloader(http.DefaultClient)
+	downloader.Configure(download.Settings{Offline: true})
+
+	err := oci.NewComponentVerifier().WithDownloadSettings(downloader).
+		VerifyComponentVersion(host, "kyma-project.io/module/istio", "1.2.0")
+
+	require.ErrorIs(t, err, download.ErrNotCached)
+}
+
+func Test_ComponentVersionReference(t *testing.T) {
+	tests := []struct {
+		name        string
+		registry    string
+		version     string
+		expectedRef string
+		expectedErr string
+	}{
+		{
+			name:        "registry with path",
+			registry:    "europe-docker.pkg.dev/kyma-project/prod/",
+			version:     "1.2.0",
+			expectedRef: "europe-docker.pkg.dev/kyma-project/prod/component-descriptors/kyma-project.io/module/istio:1.2.0",
+		},
+		{
+			name:        "build metadata",
+			registry:    "registry.example.com",
+			version:     "1.2.0+20260101",
+			expectedRef: "registry.example.com/component-descriptors/kyma-project.io/module/istio:1.2.0.build-20260101",
+		},
+		{
+			name:        "invalid registry",
+			registry:    "registry.example.com/Kyma",
+			version:     "1.2.0",
+			expectedErr: "is not a valid OCI reference",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ref, err := oci.ComponentVersionReference(test.registry, "kyma-project.io/module/istio", test.version)
+			if test.expectedErr != "" {
+				require.ErrorIs(t, err, oci.ErrInvalidArtifact)
+				require.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRef, ref.String())
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package oci
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/kyma-project/modulectl/tools/download"
+	"github.com/kyma-project/modulectl/tools/httpauth"
+)
+
+var ErrInvalidArtifact = errors.New("invalid OCI artifact")
+
+// Fetcher fetches files stored as single-layer OCI artifacts referenced as oci://registry/repository:tag or
+// oci://registry/repository@digest.
+type Fetcher struct {
+	credentials      httpauth.CredentialLookup
+	downloadSettings download.SettingsProvider
+}
+
+func NewFetcher() *Fetcher {
+	return &Fetcher{}
+}
+
+// WithCredentials authenticates against registries with the credentials of the registry host. Registries without
+// credentials fall back to the Docker config.
+func (f *Fetcher) WithCredentials(credentials httpauth.CredentialLookup) *Fetcher {
+	f.credentials = credentials
+	return f
+}
+
+// WithDownloadSettings applies the timeout and the offline mode of the download settings to all fetches.
+func (f *Fetcher) WithDownloadSettings(downloadSettings download.SettingsProvider) *Fetcher {
+	f.downloadSettings = downloadSettings
+	return f
+}
+
+// Fetch returns the content of the only layer of the artifact.
+func (f *Fetcher) Fetch(fileURL *url.URL) ([]byte, error) {
+	ref, err := ParseReference(fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, err := download.ContextFor(f.downloadSettings, fileURL.Redacted())
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	image, err := remote.Image(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(newKeychain(f.credentials)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact %s: %w", ref, err)
+	}
+
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layers of artifact %s: %w", ref, err)
+	}
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("artifact %s has %d layers, expected exactly one: %w", ref, len(layers),
+			ErrInvalidArtifact)
+	}
+
+	// The layer blob is the file itself, so it is read as stored without decompressing it.
+	blob, err := layers[0].Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layer of artifact %s: %w", ref, err)
+	}
+	defer blob.Close()
+
+	data, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layer of artifact %s: %w", ref, err)
+	}
+	return data, nil
+}
+
+// ParseReference converts the oci:// URL into an OCI reference.
+func ParseReference(fileURL *url.URL) (name.Reference, error) {
+	repository := strings.TrimPrefix(fileURL.Path, "/")
+	if fileURL.User != nil || fileURL.RawQuery != "" || fileURL.Fragment != "" || repository == "" {
+		return nil, fmt.Errorf("'%s' must be in the format oci://registry/repository:tag: %w", fileURL.Redacted(),
+			ErrInvalidArtifact)
+	}
+
+	ref, err := name.ParseReference(fileURL.Host+"/"+repository, name.StrictValidation)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid OCI reference: %w: %w", fileURL.Redacted(), ErrInvalidArtifact,
+			err)
+	}
+	return ref, nil
+}
+
+// newKeychain returns a keychain that prefers the credentials, if set, over the Docker config.
+func newKeychain(credentials httpauth.CredentialLookup) authn.Keychain {
+	if credentials == nil {
+		return authn.DefaultKeychain
+	}
+	return authn.NewMultiKeychain(&credentialsKeychain{credentials: credentials}, authn.DefaultKeychain)
+}
+
+type credentialsKeychain struct {
+	credentials httpauth.CredentialLookup
+}
+
+func (k *credentialsKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	credential, found, err := k.credentials.Lookup(resource.RegistryStr())
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up credentials for %s: %w", resource.RegistryStr(), err)
+	}
+	if !found {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(authn.AuthConfig{
+		Username:      credential.Username,
+		Password:      credential.Password,
+		RegistryToken: credential.Token,
+	}), nil
+}
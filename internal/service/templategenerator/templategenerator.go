@@ -1,15 +1,18 @@
 package templategenerator
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"strings"
 	"text/template"
 
 	"github.com/kyma-project/lifecycle-manager/api/shared"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
@@ -156,7 +159,9 @@ func (s *Service) GenerateModuleTemplate(
 		Manager:             moduleConfig.Manager,
 		RequiresDowntime:    moduleConfig.RequiresDowntime,
 	}
-	if moduleConfig.Manifest.IsURL() {
+	// Only https manifests can be fetched by consumers of the ModuleTemplate, oci and git+https manifests are not
+	// defaulted.
+	if moduleConfig.Manifest.IsURL() && moduleConfig.Manifest.URL().Scheme == contentprovider.SchemeHTTPS {
 		mtData.Resources = contentprovider.Resources{
 			// defaults rawManifest to Manifest; may be overwritten by explicitly provided entries
 			"rawManifest": moduleConfig.Manifest.String(),
@@ -164,7 +169,7 @@ func (s *Service) GenerateModuleTemplate(
 	}
 
 	if len(data) > 0 {
-		crData, err := parseDefaultCRYaml(data)
+		crData, err := parseDefaultCRYaml(data, moduleConfig.PrimaryCRD)
 		if err != nil {
 			return fmt.Errorf("failed to parse cr data: %w", err)
 		}
@@ -187,10 +192,28 @@ func (s *Service) GenerateModuleTemplate(
 	return nil
 }
 
-func parseDefaultCRYaml(data []byte) ([]byte, error) {
-	var crData map[string]any
-	if err := yaml.Unmarshal(data, &crData); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal cr data: %w", err)
+// parseDefaultCRYaml returns the default CR that is placed into the ModuleTemplate spec.data. If the default CR file
+// contains multiple documents, the document matching the primary CRD of the module is used; if no primary CRD is
+// configured or no document matches it, the first document is used.
+func parseDefaultCRYaml(data []byte, primaryCRD *metav1.GroupKind) ([]byte, error) {
+	documents, err := splitYAMLDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split cr data: %w", err)
+	}
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	crData := documents[0]
+	if primaryCRD != nil {
+		for _, document := range documents {
+			apiVersion, _ := document["apiVersion"].(string)
+			kind, _ := document["kind"].(string)
+			if strings.Split(apiVersion, "/")[0] == primaryCRD.Group && kind == primaryCRD.Kind {
+				crData = document
+				break
+			}
+		}
 	}
 
 	cr, err := yaml.Marshal(crData)
@@ -201,6 +224,31 @@ func parseDefaultCRYaml(data []byte) ([]byte, error) {
 	return cr, nil
 }
 
+func splitYAMLDocuments(data []byte) ([]map[string]any, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var documents []map[string]any
+	for {
+		document, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to read yaml document: %w", err)
+		}
+
+		var crData map[string]any
+		if err := yaml.Unmarshal(document, &crData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cr data: %w", err)
+		}
+		if len(crData) == 0 {
+			continue
+		}
+		documents = append(documents, crData)
+	}
+	return documents, nil
+}
+
 func generateLabels(config *contentprovider.ModuleConfig) map[string]string {
 	labels := config.Labels
 
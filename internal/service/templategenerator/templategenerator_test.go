@@ -106,6 +106,21 @@ spec:
 				require.NotContains(t, mockFS.writtenTemplate, commonManifest.String())
 			},
 		},
+		{
+			name: "With OCI Manifest",
+			data: defaultData,
+			moduleConfig: &contentprovider.ModuleConfig{
+				Name:        "example.com/component",
+				Version:     "1.0.0",
+				Manifest:    contentprovider.MustUrlOrLocalFile("oci://registry.example.com/manifests:1.0.0"),
+				Labels:      map[string]string{"key": "value"},
+				Annotations: map[string]string{"annotation": "value"},
+			},
+			assertions: func(t *testing.T, mockFS *mockFileSystem) {
+				t.Helper()
+				assertCommonTemplatePropertiesWithoutRawManifest(t, mockFS)
+			},
+		},
 		{
 			name: "With Associated Resources",
 			data: defaultData,
@@ -323,6 +338,63 @@ spec:
 	}
 }
 
+func TestGenerateModuleTemplate_WithMultipleDefaultCRs(t *testing.T) {
+	data := []byte(`apiVersion: operator.kyma-project.io/v1alpha1
+kind: Config
+metadata:
+  name: sample-config
+---
+apiVersion: operator.kyma-project.io/v1alpha1
+kind: Sample
+metadata:
+  name: sample-yaml
+`)
+
+	tests := []struct {
+		name       string
+		primaryCRD *metav1.GroupKind
+		wantData   string
+		wantAbsent string
+	}{
+		{
+			name:       "uses first document without primary CRD",
+			primaryCRD: nil,
+			wantData:   "name: sample-config",
+			wantAbsent: "name: sample-yaml",
+		},
+		{
+			name:       "uses document matching primary CRD",
+			primaryCRD: &metav1.GroupKind{Group: "operator.kyma-project.io", Kind: "Sample"},
+			wantData:   "name: sample-yaml",
+			wantAbsent: "name: sample-config",
+		},
+		{
+			name:       "uses first document when no document matches primary CRD",
+			primaryCRD: &metav1.GroupKind{Group: "operator.kyma-project.io", Kind: "Other"},
+			wantData:   "name: sample-config",
+			wantAbsent: "name: sample-yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := &mockFileSystem{}
+			svc, _ := templategenerator.NewService(mockFS)
+			moduleConfig := &contentprovider.ModuleConfig{
+				Name:       "example.com/component",
+				Version:    "1.0.0",
+				PrimaryCRD: tt.primaryCRD,
+			}
+
+			err := svc.GenerateModuleTemplate(moduleConfig, data, false, "output.yaml")
+
+			require.NoError(t, err)
+			require.Contains(t, mockFS.writtenTemplate, tt.wantData)
+			require.NotContains(t, mockFS.writtenTemplate, tt.wantAbsent)
+		})
+	}
+}
+
 type mockFileSystem struct {
 	path, writtenTemplate string
 }
@@ -0,0 +1,55 @@
+package variables
+
+import (
+	"fmt"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+type FileSystem interface {
+	ReadFile(path string) ([]byte, error)
+}
+
+type TempFileSystem interface {
+	WriteTempFile(dir, pattern string, data []byte) (string, error)
+}
+
+type Service struct {
+	fileSystem     FileSystem
+	tempFileSystem TempFileSystem
+}
+
+func NewService(fileSystem FileSystem, tempFileSystem TempFileSystem) (*Service, error) {
+	if fileSystem == nil {
+		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if tempFileSystem == nil {
+		return nil, fmt.Errorf("tempFileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		fileSystem:     fileSystem,
+		tempFileSystem: tempFileSystem,
+	}, nil
+}
+
+// ExpandFile expands the variables in the file and writes the result to a new temporary file matching the pattern.
+// The path of the temporary file is returned.
+func (s *Service) ExpandFile(filePath, pattern string, vars *Variables) (string, error) {
+	data, err := s.fileSystem.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	expanded, err := vars.Expand(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand variables in %s: %w", filePath, err)
+	}
+
+	expandedFilePath, err := s.tempFileSystem.WriteTempFile("", pattern, expanded)
+	if err != nil {
+		return "", fmt.Errorf("failed to write expanded file: %w", err)
+	}
+	return expandedFilePath, nil
+}
@@ -0,0 +1,122 @@
+package variables
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+const (
+	ModuleNameVariable    = "MODULE_NAME"
+	ModuleVersionVariable = "MODULE_VERSION"
+	GitCommitVariable     = "GIT_COMMIT"
+)
+
+var (
+	ErrUndefinedVariable = errors.New("undefined variable")
+	ErrInvalidVariable   = errors.New("invalid variable")
+
+	// variablePattern matches ${NAME} references, optionally escaped as $${NAME}.
+	variablePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+	namePattern     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Variables holds the values used to expand ${NAME} references in the module config, manifest and default CR.
+// Values are looked up in the following order: explicitly set values, environment variables, default values.
+type Variables struct {
+	values    map[string]string
+	defaults  map[string]string
+	lookupEnv func(string) (string, bool)
+	strict    bool
+}
+
+// New creates Variables from the given explicitly set values. In strict mode, expanding an undefined variable
+// fails; otherwise, references to undefined variables are left untouched.
+func New(values map[string]string, strict bool) *Variables {
+	vars := &Variables{
+		values:    make(map[string]string, len(values)),
+		defaults:  make(map[string]string),
+		lookupEnv: os.LookupEnv,
+		strict:    strict,
+	}
+	for name, value := range values {
+		vars.values[name] = value
+	}
+	return vars
+}
+
+// ParseAssignments parses a list of KEY=VALUE assignments as provided via the --set flag.
+func ParseAssignments(assignments []string) (map[string]string, error) {
+	values := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		name, value, found := strings.Cut(assignment, "=")
+		if !found {
+			return nil, fmt.Errorf("%q must be in the KEY=VALUE format: %w", assignment, commonerrors.ErrInvalidOption)
+		}
+		if !namePattern.MatchString(name) {
+			return nil, fmt.Errorf("%q is not a valid variable name: %w", name, ErrInvalidVariable)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// WithLookupEnv replaces the function used to look up environment variables.
+func (v *Variables) WithLookupEnv(lookupEnv func(string) (string, bool)) *Variables {
+	v.lookupEnv = lookupEnv
+	return v
+}
+
+// Lenient returns a copy of the variables that leaves references to undefined variables untouched, also in strict
+// mode.
+func (v *Variables) Lenient() *Variables {
+	lenient := *v
+	lenient.strict = false
+	return &lenient
+}
+
+// SetDefault sets a value that is used only if the variable is neither set explicitly nor in the environment.
+func (v *Variables) SetDefault(name, value string) {
+	v.defaults[name] = value
+}
+
+// Lookup returns the value of the variable.
+func (v *Variables) Lookup(name string) (string, bool) {
+	if value, ok := v.values[name]; ok {
+		return value, true
+	}
+	if value, ok := v.lookupEnv(name); ok {
+		return value, true
+	}
+	value, ok := v.defaults[name]
+	return value, ok
+}
+
+// Expand replaces all ${NAME} references in data. $${NAME} is an escape sequence that results in a literal ${NAME}.
+func (v *Variables) Expand(data []byte) ([]byte, error) {
+	var undefined []string
+	expanded := variablePattern.ReplaceAllFunc(data, func(match []byte) []byte {
+		if strings.HasPrefix(string(match), "$$") {
+			return match[1:]
+		}
+		name := string(variablePattern.FindSubmatch(match)[1])
+		value, ok := v.Lookup(name)
+		if !ok {
+			if !slices.Contains(undefined, name) {
+				undefined = append(undefined, name)
+			}
+			return match
+		}
+		return []byte(value)
+	})
+
+	if v.strict && len(undefined) > 0 {
+		return nil, fmt.Errorf("%s: %w", strings.Join(undefined, ", "), ErrUndefinedVariable)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,134 @@
+package variables_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/variables"
+)
+
+func noEnv(_ string) (string, bool) {
+	return "", false
+}
+
+func TestVariables_Expand(t *testing.T) {
+	env := map[string]string{"REGISTRY": "env.registry.io", "TEAM": "jellyfish"}
+	vars := variables.New(map[string]string{"REGISTRY": "set.registry.io"}, false).
+		WithLookupEnv(func(name string) (string, bool) {
+			value, ok := env[name]
+			return value, ok
+		})
+	vars.SetDefault(variables.ModuleVersionVariable, "1.2.3")
+	vars.SetDefault("TEAM", "default-team")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "explicit value wins over env", input: "${REGISTRY}/image", expected: "set.registry.io/image"},
+		{name: "env wins over default", input: "team: ${TEAM}", expected: "team: jellyfish"},
+		{name: "default value", input: "image:${MODULE_VERSION}", expected: "image:1.2.3"},
+		{name: "undefined variable is kept", input: "${UNDEFINED}", expected: "${UNDEFINED}"},
+		{name: "escaped variable", input: "$${MODULE_VERSION}", expected: "${MODULE_VERSION}"},
+		{name: "plain dollar is kept", input: "echo $MODULE_VERSION $1", expected: "echo $MODULE_VERSION $1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := vars.Expand([]byte(tt.input))
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(result))
+		})
+	}
+}
+
+func TestVariables_Expand_ReturnsError_WhenStrictAndUndefined(t *testing.T) {
+	vars := variables.New(map[string]string{"DEFINED": "value"}, true).WithLookupEnv(noEnv)
+
+	_, err := vars.Expand([]byte("${DEFINED} ${FIRST} ${SECOND} ${FIRST} $${ESCAPED}"))
+
+	require.ErrorIs(t, err, variables.ErrUndefinedVariable)
+	require.ErrorContains(t, err, "FIRST, SECOND")
+	require.NotContains(t, err.Error(), "ESCAPED")
+}
+
+func TestVariables_Lenient_KeepsUndefinedVariables(t *testing.T) {
+	vars := variables.New(map[string]string{"DEFINED": "value"}, true).WithLookupEnv(noEnv)
+
+	result, err := vars.Lenient().Expand([]byte("${DEFINED} ${UNDEFINED}"))
+
+	require.NoError(t, err)
+	require.Equal(t, "value ${UNDEFINED}", string(result))
+	_, err = vars.Expand([]byte("${UNDEFINED}"))
+	require.ErrorIs(t, err, variables.ErrUndefinedVariable)
+}
+
+func TestParseAssignments(t *testing.T) {
+	values, err := variables.ParseAssignments([]string{"MODULE_VERSION=1.2.3", "EMPTY=", "URL=https://a.io/?x=y"})
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"MODULE_VERSION": "1.2.3",
+		"EMPTY":          "",
+		"URL":            "https://a.io/?x=y",
+	}, values)
+}
+
+func TestParseAssignments_ReturnsError(t *testing.T) {
+	_, err := variables.ParseAssignments([]string{"MODULE_VERSION"})
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+
+	_, err = variables.ParseAssignments([]string{"1INVALID=value"})
+	require.ErrorIs(t, err, variables.ErrInvalidVariable)
+}
+
+func TestNewService_ReturnsError_WhenDependenciesAreNil(t *testing.T) {
+	_, err := variables.NewService(nil, &tempFileSystemStub{})
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+
+	_, err = variables.NewService(&fileSystemStub{}, nil)
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+}
+
+func TestService_ExpandFile(t *testing.T) {
+	tempFS := &tempFileSystemStub{}
+	svc, _ := variables.NewService(&fileSystemStub{data: "image: repo:${MODULE_VERSION}"}, tempFS)
+	vars := variables.New(map[string]string{variables.ModuleVersionVariable: "1.2.3"}, true).WithLookupEnv(noEnv)
+
+	result, err := svc.ExpandFile("manifest.yaml", "pattern-*.yaml", vars)
+
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/pattern-*.yaml", result)
+	require.Equal(t, "image: repo:1.2.3", string(tempFS.written))
+}
+
+func TestService_ExpandFile_ReturnsError_WhenReadFails(t *testing.T) {
+	svc, _ := variables.NewService(&fileSystemStub{err: errors.New("read error")}, &tempFileSystemStub{})
+
+	_, err := svc.ExpandFile("manifest.yaml", "pattern-*.yaml", variables.New(nil, false))
+
+	require.ErrorContains(t, err, "failed to read file")
+}
+
+type fileSystemStub struct {
+	data string
+	err  error
+}
+
+func (f *fileSystemStub) ReadFile(_ string) ([]byte, error) {
+	return []byte(f.data), f.err
+}
+
+type tempFileSystemStub struct {
+	written []byte
+}
+
+func (f *tempFileSystemStub) WriteTempFile(_, pattern string, data []byte) (string, error) {
+	f.written = data
+	return "/tmp/" + pattern, nil
+}
@@ -0,0 +1,119 @@
+package verifier
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+)
+
+const kindCustomResourceDefinition = "CustomResourceDefinition"
+
+// builtInKinds lists the Kubernetes built-in kinds per group/version that may be referenced as associated resources
+// without being shipped as a CRD by the module. Kinds of the core group are listed under "/v1", as the core group is
+// empty.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var builtInKinds = map[string][]string{
+	"/v1": {
+		"ConfigMap", "Endpoints", "Event", "LimitRange", "Namespace", "Node", "PersistentVolume",
+		"PersistentVolumeClaim", "Pod", "PodTemplate", "ReplicationController", "ResourceQuota", "Secret", "Service",
+		"ServiceAccount",
+	},
+	"apps/v1":                      {"ControllerRevision", "DaemonSet", "Deployment", "ReplicaSet", "StatefulSet"},
+	"batch/v1":                     {"CronJob", "Job"},
+	"autoscaling/v1":               {"HorizontalPodAutoscaler"},
+	"autoscaling/v2":               {"HorizontalPodAutoscaler"},
+	"policy/v1":                    {"PodDisruptionBudget"},
+	"networking.k8s.io/v1":         {"Ingress", "IngressClass", "NetworkPolicy"},
+	"discovery.k8s.io/v1":          {"EndpointSlice"},
+	"rbac.authorization.k8s.io/v1": {"ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding"},
+	"admissionregistration.k8s.io/v1": {
+		"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration",
+		"ValidatingAdmissionPolicy", "ValidatingAdmissionPolicyBinding",
+	},
+	"apiextensions.k8s.io/v1":   {"CustomResourceDefinition"},
+	"apiregistration.k8s.io/v1": {"APIService"},
+	"scheduling.k8s.io/v1":      {"PriorityClass"},
+	"storage.k8s.io/v1": {
+		"CSIDriver", "CSINode", "CSIStorageCapacity", "StorageClass", "VolumeAttachment",
+	},
+	"coordination.k8s.io/v1":               {"Lease"},
+	"certificates.k8s.io/v1":               {"CertificateSigningRequest"},
+	"node.k8s.io/v1":                       {"RuntimeClass"},
+	"flowcontrol.apiserver.k8s.io/v1":      {"FlowSchema", "PriorityLevelConfiguration"},
+	"events.k8s.io/v1":                     {"Event"},
+	"resource.k8s.io/v1":                   {"DeviceClass", "ResourceClaim", "ResourceClaimTemplate", "ResourceSlice"},
+	"certificates.k8s.io/v1beta1":          {"ClusterTrustBundle"},
+	"networking.k8s.io/v1beta1":            {"IPAddress", "ServiceCIDR"},
+	"admissionregistration.k8s.io/v1beta1": {"ValidatingAdmissionPolicy", "ValidatingAdmissionPolicyBinding"},
+}
+
+// VerifyAssociatedResources returns the associated resources of the module config that are neither served by a
+// CustomResourceDefinition contained in the raw manifest nor a known built-in Kubernetes kind.
+func (s *Service) VerifyAssociatedResources(moduleConfig *contentprovider.ModuleConfig,
+	filePath string,
+) ([]*metav1.GroupVersionKind, error) {
+	if len(moduleConfig.AssociatedResources) == 0 {
+		return nil, nil
+	}
+
+	resources, err := s.rawManifestParser.Parse(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse raw manifest: %w", err)
+	}
+
+	known, err := servedCRDKinds(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	var unknown []*metav1.GroupVersionKind
+	for _, gvk := range moduleConfig.AssociatedResources {
+		if _, ok := known[*gvk]; ok {
+			continue
+		}
+		if isBuiltInKind(gvk) {
+			continue
+		}
+		unknown = append(unknown, gvk)
+	}
+	return unknown, nil
+}
+
+func servedCRDKinds(resources []*unstructured.Unstructured) (map[metav1.GroupVersionKind]struct{}, error) {
+	known := make(map[metav1.GroupVersionKind]struct{})
+	for _, res := range resources {
+		if res.GetKind() != kindCustomResourceDefinition {
+			continue
+		}
+
+		group, _, _ := unstructured.NestedString(res.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(res.Object, "spec", "names", "kind")
+		versions, _, err := unstructured.NestedSlice(res.Object, "spec", "versions")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read versions of CRD %q: %w", res.GetName(), err)
+		}
+
+		for _, version := range versions {
+			versionMap, ok := version.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(versionMap, "name")
+			served, found, _ := unstructured.NestedBool(versionMap, "served")
+			if found && !served {
+				continue
+			}
+			known[metav1.GroupVersionKind{Group: group, Version: name, Kind: kind}] = struct{}{}
+		}
+	}
+	return known, nil
+}
+
+func isBuiltInKind(gvk *metav1.GroupVersionKind) bool {
+	return slices.Contains(builtInKinds[gvk.Group+"/"+gvk.Version], gvk.Kind)
+}
@@ -0,0 +1,122 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/verifier"
+)
+
+func makeCRD(group, kind string, versions map[string]bool) *unstructured.Unstructured {
+	crdVersions := make([]any, 0, len(versions))
+	for name, served := range versions {
+		crdVersions = append(crdVersions, map[string]any{"name": name, "served": served})
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": kind + "." + group},
+		"spec": map[string]any{
+			"group":    group,
+			"names":    map[string]any{"kind": kind},
+			"versions": crdVersions,
+		},
+	}}
+}
+
+func TestService_VerifyAssociatedResources(t *testing.T) {
+	resources := []*unstructured.Unstructured{
+		makeCRD("operator.kyma-project.io", "Sample", map[string]bool{"v1alpha1": false, "v1": true}),
+	}
+
+	tests := []struct {
+		name        string
+		associated  []*metav1.GroupVersionKind
+		wantUnknown []*metav1.GroupVersionKind
+	}{
+		{
+			name:        "No associated resources",
+			associated:  nil,
+			wantUnknown: nil,
+		},
+		{
+			name: "Associated resource served by CRD in manifest",
+			associated: []*metav1.GroupVersionKind{
+				{Group: "operator.kyma-project.io", Version: "v1", Kind: "Sample"},
+			},
+			wantUnknown: nil,
+		},
+		{
+			name: "Associated resource with version not served by CRD",
+			associated: []*metav1.GroupVersionKind{
+				{Group: "operator.kyma-project.io", Version: "v1alpha1", Kind: "Sample"},
+			},
+			wantUnknown: []*metav1.GroupVersionKind{
+				{Group: "operator.kyma-project.io", Version: "v1alpha1", Kind: "Sample"},
+			},
+		},
+		{
+			name: "Associated resource is a built-in kind",
+			associated: []*metav1.GroupVersionKind{
+				{Group: "apps", Version: "v1", Kind: "Deployment"},
+				{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"},
+			},
+			wantUnknown: nil,
+		},
+		{
+			name: "Associated resource is a built-in kind of the core group",
+			associated: []*metav1.GroupVersionKind{
+				{Group: "", Version: "v1", Kind: "ConfigMap"},
+				{Group: "", Version: "v1", Kind: "Secret"},
+				{Group: "", Version: "v1", Kind: "PersistentVolumeClaim"},
+			},
+			wantUnknown: nil,
+		},
+		{
+			name: "Associated resource of the core group with unknown kind",
+			associated: []*metav1.GroupVersionKind{
+				{Group: "", Version: "v1", Kind: "Sample"},
+			},
+			wantUnknown: []*metav1.GroupVersionKind{
+				{Group: "", Version: "v1", Kind: "Sample"},
+			},
+		},
+		{
+			name: "Associated resource not shipped by the module",
+			associated: []*metav1.GroupVersionKind{
+				{Group: "operator.kyma-project.io", Version: "v1", Kind: "Sample"},
+				{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Gateway"},
+			},
+			wantUnknown: []*metav1.GroupVersionKind{
+				{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Gateway"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := verifier.NewService(&fakeParser{resources: resources})
+			cfg := &contentprovider.ModuleConfig{AssociatedResources: tt.associated}
+
+			unknown, err := svc.VerifyAssociatedResources(cfg, "dummy.yaml")
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantUnknown, unknown)
+		})
+	}
+}
+
+func TestService_VerifyAssociatedResources_ParseError(t *testing.T) {
+	svc := verifier.NewService(&fakeParserWithError{})
+	cfg := &contentprovider.ModuleConfig{
+		AssociatedResources: []*metav1.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "Deployment"}},
+	}
+
+	_, err := svc.VerifyAssociatedResources(cfg, "dummy.yaml")
+
+	require.ErrorIs(t, err, errParse)
+}
@@ -3,8 +3,9 @@ package verifier
 import (
 	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/Masterminds/semver/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/image"
 )
 
 type Service struct {
@@ -21,6 +23,13 @@ type Service struct {
 var (
 	errImageNoTag            = errors.New("no image tag")
 	errNoMatchedVersionFound = errors.New("no matched version found")
+	errManagerNotFound       = errors.New("manager resource not found")
+	errContainerNotFound     = errors.New("manager container not found")
+)
+
+const (
+	containerTypeInit    = "initContainer"
+	containerTypeRegular = "container"
 )
 
 func NewService(parser types.RawManifestParser) *Service {
@@ -44,6 +53,56 @@ func (s *Service) VerifyModuleResources(moduleConfig *contentprovider.ModuleConf
 	return nil
 }
 
+// ContainerComparison describes the outcome of comparing a single container image against the module version.
+type ContainerComparison struct {
+	Container string
+	Type      string
+	Image     string
+	Tag       string
+	Matched   bool
+	Reason    string
+}
+
+func (c ContainerComparison) String() string {
+	result := "no match"
+	if c.Matched {
+		result = "match"
+	}
+	msg := fmt.Sprintf("%s %q image %q", c.Type, c.Container, c.Image)
+	if c.Tag != "" {
+		msg += fmt.Sprintf(" (tag %q)", c.Tag)
+	}
+	msg += ": " + result
+	if c.Reason != "" {
+		msg += ", " + c.Reason
+	}
+	return msg
+}
+
+// VerificationReport collects all comparisons made while looking for the module version in the manager.
+type VerificationReport struct {
+	Resource    string
+	Version     string
+	Comparisons []ContainerComparison
+}
+
+func (r *VerificationReport) Matched() bool {
+	for _, comparison := range r.Comparisons {
+		if comparison.Matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *VerificationReport) String() string {
+	lines := make([]string, 0, len(r.Comparisons))
+	for _, comparison := range r.Comparisons {
+		lines = append(lines, "  - "+comparison.String())
+	}
+	return fmt.Sprintf("compared version %s against %s:\n%s", r.Version, r.Resource, strings.Join(lines, "\n"))
+}
+
 func verifyModuleImageVersion(resources []*unstructured.Unstructured, version string,
 	manager *contentprovider.Manager,
 ) error {
@@ -54,56 +113,104 @@ func verifyModuleImageVersion(resources []*unstructured.Unstructured, version st
 			continue
 		}
 
-		switch kind {
-		case "Deployment":
-			var deploy appsv1.Deployment
-			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(res.Object, &deploy); err != nil {
-				return fmt.Errorf("failed to convert unstructured to Deployment: %w", err)
-			}
-			if foundMatchedVersionInDeployment(deploy, version) {
-				return nil
-			}
-		case "StatefulSet":
-			var statefulSet appsv1.StatefulSet
-			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(res.Object, &statefulSet); err != nil {
-				return fmt.Errorf("failed to convert unstructured to StatefulSet: %w", err)
-			}
-			if foundMatchedVersionInStatefulSet(statefulSet, version) {
-				return nil
-			}
+		podSpec, found, err := getPodSpec(res)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+
+		report, err := compareContainers(podSpec, version, manager.ContainerName)
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", kind, name, err)
+		}
+		report.Resource = fmt.Sprintf("%s %q", kind, name)
+		if report.Matched() {
+			return nil
 		}
+		return fmt.Errorf("no matched version %s found in %s %q, %s: %w",
+			version, kind, name, report.String(), errNoMatchedVersionFound)
 	}
-	return fmt.Errorf("no matched version %s found in Deployment or StatefulSet: %w", version, errNoMatchedVersionFound)
+	return fmt.Errorf("no matched version %s found in Deployment or StatefulSet %q: %w: %w",
+		version, manager.Name, errManagerNotFound, errNoMatchedVersionFound)
 }
 
-func foundMatchedVersionInContainers(containers []corev1.Container, version string) bool {
-	for _, c := range containers {
-		imageTag, err := getImageTag(c.Image)
-		if err != nil {
-			return false
+func getPodSpec(res *unstructured.Unstructured) (corev1.PodSpec, bool, error) {
+	switch res.GetKind() {
+	case contentprovider.KindDeployment:
+		var deploy appsv1.Deployment
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(res.Object, &deploy); err != nil {
+			return corev1.PodSpec{}, false, fmt.Errorf("failed to convert unstructured to Deployment: %w", err)
 		}
-		if imageTag == version {
-			return true
+		return deploy.Spec.Template.Spec, true, nil
+	case contentprovider.KindStatefulSet:
+		var statefulSet appsv1.StatefulSet
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(res.Object, &statefulSet); err != nil {
+			return corev1.PodSpec{}, false, fmt.Errorf("failed to convert unstructured to StatefulSet: %w", err)
 		}
+		return statefulSet.Spec.Template.Spec, true, nil
 	}
-	return false
+	return corev1.PodSpec{}, false, nil
+}
+
+// compareContainers compares the images of all init, sidecar and regular containers of the pod spec with the
+// version. If containerName is set, only the container with that name is compared.
+func compareContainers(podSpec corev1.PodSpec, version, containerName string) (*VerificationReport, error) {
+	report := &VerificationReport{Version: version}
+
+	for _, c := range podSpec.InitContainers {
+		if containerName == "" || c.Name == containerName {
+			report.Comparisons = append(report.Comparisons, compareContainer(c, containerTypeInit, version))
+		}
+	}
+	for _, c := range podSpec.Containers {
+		if containerName == "" || c.Name == containerName {
+			report.Comparisons = append(report.Comparisons, compareContainer(c, containerTypeRegular, version))
+		}
+	}
+
+	if containerName != "" && len(report.Comparisons) == 0 {
+		return nil, fmt.Errorf("container %q: %w", containerName, errContainerNotFound)
+	}
+	return report, nil
 }
 
-func foundMatchedVersionInDeployment(deploy appsv1.Deployment, version string) bool {
-	return foundMatchedVersionInContainers(deploy.Spec.Template.Spec.Containers, version)
+func compareContainer(container corev1.Container, containerType, version string) ContainerComparison {
+	comparison := ContainerComparison{
+		Container: container.Name,
+		Type:      containerType,
+		Image:     container.Image,
+	}
+
+	imageTag, err := getImageTag(container.Image)
+	if err != nil {
+		comparison.Reason = err.Error()
+		return comparison
+	}
+	comparison.Tag = imageTag
+	comparison.Matched = versionsEqual(imageTag, version)
+	return comparison
 }
 
-func foundMatchedVersionInStatefulSet(statefulSet appsv1.StatefulSet, version string) bool {
-	return foundMatchedVersionInContainers(statefulSet.Spec.Template.Spec.Containers, version)
+// versionsEqual compares the image tag with the module version. Both are parsed as semantic versions, so that
+// prefixed tags like "v1.2.3" match "1.2.3". Tags that are not semantic versions are compared literally.
+func versionsEqual(imageTag, version string) bool {
+	tagVersion, tagErr := semver.StrictNewVersion(strings.TrimPrefix(imageTag, "v"))
+	moduleVersion, versionErr := semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+	if tagErr != nil || versionErr != nil {
+		return imageTag == version
+	}
+	return tagVersion.Equal(moduleVersion) && tagVersion.Metadata() == moduleVersion.Metadata()
 }
 
-func getImageTag(image string) (string, error) {
-	ref, err := name.ParseReference(image)
+func getImageTag(imageURL string) (string, error) {
+	info, err := image.ParseImageInfo(imageURL)
 	if err != nil {
-		return "", fmt.Errorf("invalid image reference: %w", err)
+		return "", err
 	}
-	if tag, ok := ref.(name.Tag); ok {
-		return tag.TagStr(), nil
+	if info.Tag == "" {
+		return "", errImageNoTag
 	}
-	return "", errImageNoTag
+	return info.Tag, nil
 }
@@ -25,6 +25,23 @@ func makeUnstructuredFromObj(obj any) *unstructured.Unstructured {
 	return u
 }
 
+const testDigest = "1234567890123456789012345678901234567890123456789012345678901234"
+
+func makeDeployment(name string, initContainers, containers []corev1.Container) *unstructured.Unstructured {
+	return makeUnstructuredFromObj(&appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: initContainers,
+					Containers:     containers,
+				},
+			},
+		},
+	})
+}
+
 type fakeParser struct {
 	resources []*unstructured.Unstructured
 }
@@ -182,6 +199,103 @@ func TestService_VerifyModuleResources(t *testing.T) {
 			manager: &contentprovider.Manager{Name: "test-manager", GroupVersionKind: *gvkDeployment},
 			wantErr: true,
 		},
+		{
+			name: "Deployment with digest-only image before matching container",
+			resources: []*unstructured.Unstructured{
+				makeDeployment("test-manager", nil, []corev1.Container{
+					{Name: "proxy", Image: "repo/proxy@sha256:" + testDigest},
+					{Name: "manager", Image: "repo/test-manager:1.2.3"},
+				}),
+			},
+			version: "1.2.3",
+			manager: &contentprovider.Manager{Name: "test-manager", GroupVersionKind: *gvkDeployment},
+			wantErr: false,
+		},
+		{
+			name: "Deployment with tag and digest image",
+			resources: []*unstructured.Unstructured{
+				makeDeployment("test-manager", nil, []corev1.Container{
+					{Name: "manager", Image: "repo/test-manager:1.2.3@sha256:" + testDigest},
+				}),
+			},
+			version: "1.2.3",
+			manager: &contentprovider.Manager{Name: "test-manager", GroupVersionKind: *gvkDeployment},
+			wantErr: false,
+		},
+		{
+			name: "Deployment with v-prefixed image tag",
+			resources: []*unstructured.Unstructured{
+				makeDeployment("test-manager", nil, []corev1.Container{
+					{Name: "manager", Image: "repo/test-manager:v1.2.3"},
+				}),
+			},
+			version: "1.2.3",
+			manager: &contentprovider.Manager{Name: "test-manager", GroupVersionKind: *gvkDeployment},
+			wantErr: false,
+		},
+		{
+			name: "Deployment with partial version image tag",
+			resources: []*unstructured.Unstructured{
+				makeDeployment("test-manager", nil, []corev1.Container{
+					{Name: "manager", Image: "repo/test-manager:1.2"},
+				}),
+			},
+			version: "1.2.0",
+			manager: &contentprovider.Manager{Name: "test-manager", GroupVersionKind: *gvkDeployment},
+			wantErr: true,
+		},
+		{
+			name: "Deployment with matching init container",
+			resources: []*unstructured.Unstructured{
+				makeDeployment("test-manager",
+					[]corev1.Container{{Name: "init", Image: "repo/test-manager-init:1.2.3"}},
+					[]corev1.Container{{Name: "manager", Image: "repo/test-manager:2.0.0"}}),
+			},
+			version: "1.2.3",
+			manager: &contentprovider.Manager{Name: "test-manager", GroupVersionKind: *gvkDeployment},
+			wantErr: false,
+		},
+		{
+			name: "Deployment with matching configured container",
+			resources: []*unstructured.Unstructured{
+				makeDeployment("test-manager", nil, []corev1.Container{
+					{Name: "proxy", Image: "repo/proxy:2.0.0"},
+					{Name: "manager", Image: "repo/test-manager:1.2.3"},
+				}),
+			},
+			version: "1.2.3",
+			manager: &contentprovider.Manager{
+				Name: "test-manager", GroupVersionKind: *gvkDeployment, ContainerName: "manager",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Deployment with non-matching configured container",
+			resources: []*unstructured.Unstructured{
+				makeDeployment("test-manager", nil, []corev1.Container{
+					{Name: "proxy", Image: "repo/proxy:1.2.3"},
+					{Name: "manager", Image: "repo/test-manager:2.0.0"},
+				}),
+			},
+			version: "1.2.3",
+			manager: &contentprovider.Manager{
+				Name: "test-manager", GroupVersionKind: *gvkDeployment, ContainerName: "manager",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Deployment without configured container",
+			resources: []*unstructured.Unstructured{
+				makeDeployment("test-manager", nil, []corev1.Container{
+					{Name: "manager", Image: "repo/test-manager:1.2.3"},
+				}),
+			},
+			version: "1.2.3",
+			manager: &contentprovider.Manager{
+				Name: "test-manager", GroupVersionKind: *gvkDeployment, ContainerName: "other",
+			},
+			wantErr: true,
+		},
 		{
 			name:      "No resources",
 			resources: []*unstructured.Unstructured{},
@@ -232,6 +346,28 @@ func TestService_VerifyModuleResources_ParseError(t *testing.T) {
 	require.ErrorIs(t, err, errParse)
 }
 
+func TestService_VerifyModuleResources_ReportsComparedContainers(t *testing.T) {
+	parser := fakeParser{resources: []*unstructured.Unstructured{
+		makeDeployment("test-manager",
+			[]corev1.Container{{Name: "init", Image: "repo/init@sha256:" + testDigest}},
+			[]corev1.Container{{Name: "manager", Image: "repo/test-manager:v2.0.0"}}),
+	}}
+	svc := verifier.NewService(&parser)
+	cfg := &contentprovider.ModuleConfig{
+		Version: "1.2.3",
+		Manager: &contentprovider.Manager{
+			Name:             "test-manager",
+			GroupVersionKind: metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+	}
+
+	err := svc.VerifyModuleResources(cfg, "dummy.yaml")
+
+	require.Error(t, err)
+	require.ErrorContains(t, err, `initContainer "init" image "repo/init@sha256:`+testDigest+`": no match`)
+	require.ErrorContains(t, err, `container "manager" image "repo/test-manager:v2.0.0" (tag "v2.0.0"): no match`)
+}
+
 type fakeParserWithError struct{}
 
 var errParse = errors.New("parse error")
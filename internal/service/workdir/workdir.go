@@ -0,0 +1,128 @@
+package workdir
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/tools/filesystem"
+)
+
+const (
+	workDirPattern = "modulectl-create-*"
+	tmpDirPattern  = "tmp-*"
+	dirPerm        = 0o755
+	filePerm       = 0o644
+)
+
+type TempFileSystem interface {
+	SetDir(dir string)
+}
+
+// Service manages the work directory of a single run. Temporary files of the run are created in a new tmp-*
+// subdirectory, files that must outlive the run are copied into the work directory with stable names.
+type Service struct {
+	tempFileSystem TempFileSystem
+
+	dir       string
+	tmpDir    string
+	created   bool
+	keep      bool
+	persisted []string
+}
+
+func NewService(tempFileSystem TempFileSystem) (*Service, error) {
+	if tempFileSystem == nil {
+		return nil, fmt.Errorf("tempFileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		tempFileSystem: tempFileSystem,
+	}, nil
+}
+
+// Init creates the work directory and returns its absolute path. If dir is empty, a new directory is created in the
+// default directory for temporary files. Unless keep is set, the work directory is cleaned up by Finish.
+func (s *Service) Init(dir string, keep bool) (string, error) {
+	if dir == "" {
+		tmpDir, err := os.MkdirTemp("", workDirPattern)
+		if err != nil {
+			return "", fmt.Errorf("failed to create temporary work dir: %w", err)
+		}
+		dir, s.created = tmpDir, true
+	} else {
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			s.created = true
+		}
+		if err := os.MkdirAll(dir, dirPerm); err != nil {
+			return "", fmt.Errorf("failed to create work dir %s: %w", dir, err)
+		}
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path of work dir %s: %w", dir, err)
+	}
+	s.dir, s.keep, s.persisted = absDir, keep, nil
+
+	// A new directory is created for the temporary files, so that the cleanup never removes existing files.
+	tmpDir, err := os.MkdirTemp(s.dir, tmpDirPattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary files dir in %s: %w", s.dir, err)
+	}
+	s.tmpDir = tmpDir
+	s.tempFileSystem.SetDir(tmpDir)
+	return s.dir, nil
+}
+
+// Persist copies the file into the work directory with the given name and returns the path of the copy.
+func (s *Service) Persist(filePath, name string) (string, error) {
+	if s.dir == "" {
+		return "", fmt.Errorf("work dir is not initialized: %w", commonerrors.ErrInvalidArg)
+	}
+
+	target := filepath.Join(s.dir, name)
+	if err := filesystem.CopyFile(filePath, target, filePerm); err != nil {
+		return "", fmt.Errorf("failed to copy %s to work dir: %w", filePath, err)
+	}
+	s.persisted = append(s.persisted, target)
+	return target, nil
+}
+
+// Finish cleans up the work directory unless it is kept. After a successful run, only the temporary files are
+// removed, the persisted files remain. After a failed run, all files created by the run are removed.
+func (s *Service) Finish(success bool) error {
+	s.tempFileSystem.SetDir("")
+
+	if s.dir == "" || s.keep {
+		return nil
+	}
+	return s.cleanup(success)
+}
+
+func (s *Service) cleanup(success bool) error {
+	if s.created && (!success || len(s.persisted) == 0) {
+		if err := os.RemoveAll(s.dir); err != nil {
+			return fmt.Errorf("failed to remove work dir %s: %w", s.dir, err)
+		}
+		return nil
+	}
+
+	var errs []error
+	if err := os.RemoveAll(s.tmpDir); err != nil {
+		errs = append(errs, err)
+	}
+	if !success {
+		for _, file := range s.persisted {
+			if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to clean up work dir %s: %w", s.dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package workdir_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/workdir"
+)
+
+func Test_NewService_ReturnsError_WhenTempFileSystemIsNil(t *testing.T) {
+	_, err := workdir.NewService(nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+}
+
+func Test_Init_CreatesTemporaryWorkDir_AndSetsTempDir(t *testing.T) {
+	tempFileSystem := &tempFileSystemStub{}
+	svc, _ := workdir.NewService(tempFileSystem)
+
+	dir, err := svc.Init("", true)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	assert.DirExists(t, dir)
+	assert.Equal(t, dir, filepath.Dir(tempFileSystem.dir))
+	assert.DirExists(t, tempFileSystem.dir)
+}
+
+func Test_Finish_RemovesTemporaryFiles_AndKeepsPersistedFiles_OnSuccess(t *testing.T) {
+	tempFileSystem := &tempFileSystemStub{}
+	svc, _ := workdir.NewService(tempFileSystem)
+	dir := filepath.Join(t.TempDir(), "work")
+	_, err := svc.Init(dir, false)
+	require.NoError(t, err)
+	tmpDir := tempFileSystem.dir
+
+	tmpFile := writeFile(t, filepath.Join(tmpDir, "kyma-module-manifest-123.yaml"), "manifest")
+	persisted, err := svc.Persist(tmpFile, "raw-manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "raw-manifest.yaml"), persisted)
+
+	require.NoError(t, svc.Finish(true))
+
+	assert.NoDirExists(t, tmpDir)
+	content, err := os.ReadFile(persisted)
+	require.NoError(t, err)
+	assert.Equal(t, "manifest", string(content))
+}
+
+func Test_Finish_RemovesCreatedWorkDir_OnFailure(t *testing.T) {
+	tempFileSystem := &tempFileSystemStub{}
+	svc, _ := workdir.NewService(tempFileSystem)
+	dir := filepath.Join(t.TempDir(), "work")
+	_, err := svc.Init(dir, false)
+	require.NoError(t, err)
+	tmpFile := writeFile(t, filepath.Join(tempFileSystem.dir, "kyma-module-manifest-123.yaml"), "manifest")
+	_, err = svc.Persist(tmpFile, "raw-manifest.yaml")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Finish(false))
+
+	assert.NoDirExists(t, dir)
+}
+
+func Test_Finish_RemovesOnlyCreatedFiles_OnFailure_WhenWorkDirExisted(t *testing.T) {
+	tempFileSystem := &tempFileSystemStub{}
+	svc, _ := workdir.NewService(tempFileSystem)
+	dir := t.TempDir()
+	existing := writeFile(t, filepath.Join(dir, "existing.yaml"), "existing")
+	existingTmp := writeFile(t, filepath.Join(dir, "tmp", "existing.yaml"), "existing")
+	_, err := svc.Init(dir, false)
+	require.NoError(t, err)
+	tmpDir := tempFileSystem.dir
+	tmpFile := writeFile(t, filepath.Join(tmpDir, "kyma-module-manifest-123.yaml"), "manifest")
+	persisted, err := svc.Persist(tmpFile, "raw-manifest.yaml")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Finish(false))
+
+	assert.FileExists(t, existing)
+	assert.FileExists(t, existingTmp)
+	assert.NoFileExists(t, persisted)
+	assert.NoDirExists(t, tmpDir)
+}
+
+func Test_Finish_KeepsWorkDir_WhenKeepIsSet(t *testing.T) {
+	tempFileSystem := &tempFileSystemStub{}
+	svc, _ := workdir.NewService(tempFileSystem)
+	dir := filepath.Join(t.TempDir(), "work")
+	_, err := svc.Init(dir, true)
+	require.NoError(t, err)
+	tmpFile := writeFile(t, filepath.Join(tempFileSystem.dir, "kyma-module-manifest-123.yaml"), "manifest")
+
+	require.NoError(t, svc.Finish(false))
+
+	assert.FileExists(t, tmpFile)
+	assert.Empty(t, tempFileSystem.dir)
+}
+
+func Test_Persist_ReturnsError_WhenNotInitialized(t *testing.T) {
+	svc, _ := workdir.NewService(&tempFileSystemStub{})
+
+	_, err := svc.Persist("manifest.yaml", "raw-manifest.yaml")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+}
+
+func writeFile(t *testing.T, filePath, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+	return filePath
+}
+
+type tempFileSystemStub struct {
+	dir string
+}
+
+func (s *tempFileSystemStub) SetDir(dir string) {
+	s.dir = dir
+}
@@ -4,9 +4,16 @@ package create_test
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -64,6 +71,8 @@ const (
 	withManifestNoImages          = validConfigs + "with-manifest-no-deployment-statefulset.yaml"
 	withSecurityScanDisabled      = validConfigs + "with-securityScanEnabled-false.yaml"
 	withSecurityScanEnabled       = validConfigs + "with-securityScanEnabled-true.yaml"
+	withoutRepositoryConfig       = validConfigs + "without-repository.yaml"
+	withOtherRepositoryConfig     = validConfigs + "with-other-repository.yaml"
 
 	templateOutputPath   = "/tmp/template.yaml"
 	templateOperatorPath = "../../../"
@@ -77,6 +86,8 @@ type createCmd struct {
 	moduleSourcesGitDirectory string
 	skipVersionValidation     bool
 	outputConstructorFile     string
+	// stdout is the combined output of the last execution.
+	stdout string
 }
 
 func (cmd *createCmd) execute() error {
@@ -108,8 +119,35 @@ func (cmd *createCmd) execute() error {
 
 	command = exec.Command("modulectl", args...)
 	cmdOut, err := command.CombinedOutput()
+	cmd.stdout = string(cmdOut)
 	if err != nil {
 		return fmt.Errorf("create command failed with output: %s and error: %w", cmdOut, err)
 	}
 	return nil
 }
+
+// newModuleSourcesRepository creates a git repository with a single commit as module sources. If remoteURL is not
+// empty, it is added as origin remote.
+func newModuleSourcesRepository(remoteURL string) string {
+	repoPath, err := os.MkdirTemp("", "module-sources-*")
+	Expect(err).ToNot(HaveOccurred())
+	DeferCleanup(os.RemoveAll, repoPath)
+
+	repo, err := gogit.PlainInit(repoPath, false)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(os.WriteFile(filepath.Join(repoPath, "README.md"), []byte("module sources"), 0o600)).To(Succeed())
+	worktree, err := repo.Worktree()
+	Expect(err).ToNot(HaveOccurred())
+	_, err = worktree.Add("README.md")
+	Expect(err).ToNot(HaveOccurred())
+	_, err = worktree.Commit("initial commit", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "e2e", Email: "e2e@example.com", When: time.Now()},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	if remoteURL != "" {
+		_, err = repo.CreateRemote(&config.RemoteConfig{Name: gogit.DefaultRemoteName, URLs: []string{remoteURL}})
+		Expect(err).ToNot(HaveOccurred())
+	}
+	return repoPath
+}
@@ -99,10 +99,10 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 
 	It("Given 'modulectl create' command", func() {
 		var cmd createCmd
-		By("When invoked with missing repository", func() {
+		By("When invoked with missing repository and module sources without a git remote", func() {
 			cmd = createCmd{
 				moduleConfigFile:          missingRepositoryConfig,
-				moduleSourcesGitDirectory: templateOperatorPath,
+				moduleSourcesGitDirectory: newModuleSourcesRepository(""),
 			}
 		})
 		By("Then the command should fail", func() {
@@ -110,7 +110,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate repository: must not be empty: invalid Option"))
+			).Should(ContainSubstring("failed to resolve repository: repository is not configured and can't be inferred from the git remote"))
 		})
 	})
 
@@ -336,6 +336,67 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 		})
 	})
 
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked without repository and module sources with an https git remote", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withoutRepositoryConfig,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: newModuleSourcesRepository("https://github.com/kyma-project/template-operator.git"),
+			}
+		})
+		By("Then the command should succeed with the repository of the git remote", func() {
+			Expect(cmd.execute()).To(Succeed())
+			Expect(cmd.stdout).To(ContainSubstring(
+				"Using repository https://github.com/kyma-project/template-operator of the origin git remote"))
+
+			template, err := readModuleTemplate(templateOutputPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.Spec.Info.Repository).To(Equal("https://github.com/kyma-project/template-operator"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked without repository and module sources with an SSH git remote", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withoutRepositoryConfig,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: newModuleSourcesRepository("git@github.com:kyma-project/template-operator.git"),
+			}
+		})
+		By("Then the command should succeed with the https URL of the git remote", func() {
+			Expect(cmd.execute()).To(Succeed())
+			Expect(cmd.stdout).To(ContainSubstring(
+				"Using repository https://github.com/kyma-project/template-operator of the origin git remote"))
+
+			template, err := readModuleTemplate(templateOutputPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.Spec.Info.Repository).To(Equal("https://github.com/kyma-project/template-operator"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with a repository that differs from the git remote", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withOtherRepositoryConfig,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: newModuleSourcesRepository("git@github.com:kyma-project/template-operator.git"),
+			}
+		})
+		By("Then the command should succeed with the configured repository and warn about the mismatch", func() {
+			Expect(cmd.execute()).To(Succeed())
+			Expect(cmd.stdout).To(ContainSubstring(
+				"WARNING: configured repository https://github.com/kyma-project/other-operator differs from the " +
+					"repository https://github.com/kyma-project/template-operator of the origin git remote"))
+
+			template, err := readModuleTemplate(templateOutputPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(template.Spec.Info.Repository).To(Equal("https://github.com/kyma-project/other-operator"))
+		})
+	})
+
 	// Happy path tests using the component constructor path
 
 	It("Given 'modulectl create' command", func() {
@@ -0,0 +1,122 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	cacheDirPerm  = 0o700
+	cacheFilePerm = 0o600
+)
+
+// DefaultCacheDir returns the download cache directory in the user cache directory, which is $XDG_CACHE_HOME on
+// Linux.
+func DefaultCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine user cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "modulectl", "downloads"), nil
+}
+
+// cache stores downloaded files content-addressed by their SHA-256 digest in blobs/sha256/. The index/ directory
+// maps the URLs to the digest and the ETag and Last-Modified headers of the response they were downloaded with.
+type cache struct {
+	dir string
+}
+
+type entry struct {
+	URL          string `yaml:"url"`
+	ETag         string `yaml:"etag,omitempty"`
+	LastModified string `yaml:"lastModified,omitempty"`
+	Digest       string `yaml:"digest"`
+
+	data []byte
+}
+
+// lookup returns the cached entry of the URL with its content, or nil if the URL is not cached or the cached
+// content is corrupted.
+func (c *cache) lookup(fileURL *url.URL) *entry {
+	indexData, err := os.ReadFile(c.indexPath(fileURL))
+	if err != nil {
+		return nil
+	}
+	cached := &entry{}
+	if err := yaml.Unmarshal(indexData, cached); err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.blobPath(cached.Digest))
+	if err != nil || digest(data) != cached.Digest {
+		return nil
+	}
+	cached.data = data
+	return cached
+}
+
+func (c *cache) store(fileURL *url.URL, etag, lastModified string, data []byte) error {
+	cached := entry{
+		URL:          fileURL.Redacted(),
+		ETag:         etag,
+		LastModified: lastModified,
+		Digest:       digest(data),
+	}
+	if err := writeFileAtomically(c.blobPath(cached.Digest), data); err != nil {
+		return err
+	}
+
+	indexData, err := yaml.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache index entry: %w", err)
+	}
+	return writeFileAtomically(c.indexPath(fileURL), indexData)
+}
+
+func (c *cache) indexPath(fileURL *url.URL) string {
+	return filepath.Join(c.dir, "index", digest([]byte(fileURL.String()))+".yaml")
+}
+
+func (c *cache) blobPath(digest string) string {
+	return filepath.Join(c.dir, "blobs", "sha256", digest)
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFileAtomically writes the file via a temp file, so that concurrent runs never read partially written files.
+func writeFileAtomically(filePath string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), cacheDirPerm); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmpFile.Chmod(cacheFilePerm); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), filePath); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
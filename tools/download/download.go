@@ -0,0 +1,218 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	DefaultTimeout = 20 * time.Second
+	DefaultRetries = 3
+	defaultBackoff = time.Second
+)
+
+var (
+	ErrNotCached     = errors.New("file is not cached")
+	errBadHTTPStatus = errors.New("bad http status")
+)
+
+// Settings control how files are downloaded during a single run.
+type Settings struct {
+	// Offline serves all files from the cache and fails for files that are not cached.
+	Offline bool
+	// Timeout is the timeout of a single download attempt. Zero means DefaultTimeout.
+	Timeout time.Duration
+	// Retries is the number of times a download is retried after a network error or a server error response.
+	Retries int
+	// Context is the context of the run, which cancels all downloads and fetches when it is done. Nil means
+	// context.Background().
+	Context context.Context //nolint:containedctx // the settings are scoped to a single run
+}
+
+// SettingsProvider provides the download settings of the current run, e.g. the Downloader.
+type SettingsProvider interface {
+	Settings() Settings
+}
+
+// Downloader downloads files over HTTP. If a cache directory is set, downloaded files are cached and revalidated
+// with the ETag and Last-Modified response headers on later downloads.
+type Downloader struct {
+	httpClient *http.Client
+	cache      *cache
+	settings   Settings
+	backoff    time.Duration
+}
+
+func NewDownloader(httpClient *http.Client) *Downloader {
+	return &Downloader{
+		httpClient: httpClient,
+		settings: Settings{
+			Timeout: DefaultTimeout,
+			Retries: DefaultRetries,
+		},
+		backoff: defaultBackoff,
+	}
+}
+
+// WithCacheDir enables caching of downloaded files in the directory.
+func (d *Downloader) WithCacheDir(cacheDir string) *Downloader {
+	d.cache = &cache{dir: cacheDir}
+	return d
+}
+
+// WithBackoff sets the delay before the first retry, which is doubled for every further retry.
+func (d *Downloader) WithBackoff(backoff time.Duration) *Downloader {
+	d.backoff = backoff
+	return d
+}
+
+// Configure applies the settings to all following downloads.
+func (d *Downloader) Configure(settings Settings) {
+	if settings.Timeout <= 0 {
+		settings.Timeout = DefaultTimeout
+	}
+	d.settings = settings
+}
+
+// Settings returns the settings of the following downloads.
+func (d *Downloader) Settings() Settings {
+	return d.settings
+}
+
+// FetchContext returns a context with the timeout of a single download attempt for fetches that bypass the
+// Downloader, e.g. of OCI artifacts or git repositories. Such fetches are not cached, so the resource is not
+// available in offline mode.
+func (s Settings) FetchContext(resource string) (context.Context, context.CancelFunc, error) {
+	if s.Offline {
+		return nil, nil, fmt.Errorf("%s is not available in offline mode: %w", resource, ErrNotCached)
+	}
+
+	ctx, cancel := s.attemptContext()
+	return ctx, cancel, nil
+}
+
+// attemptContext returns a context of the run context with the timeout of a single download attempt.
+func (s Settings) attemptContext() (context.Context, context.CancelFunc) {
+	parent := s.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// ContextFor returns the FetchContext of the settings of the provider. A nil provider results in the default
+// settings.
+func ContextFor(provider SettingsProvider, resource string) (context.Context, context.CancelFunc, error) {
+	var settings Settings
+	if provider != nil {
+		settings = provider.Settings()
+	}
+	return settings.FetchContext(resource)
+}
+
+// Download returns the content of the URL. Errors contain the URL with the password redacted.
+func (d *Downloader) Download(fileURL *url.URL) ([]byte, error) {
+	var cached *entry
+	if d.cache != nil {
+		cached = d.cache.lookup(fileURL)
+	}
+
+	if d.settings.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf("%s is not available in offline mode: %w", fileURL.Redacted(), ErrNotCached)
+		}
+		return cached.data, nil
+	}
+
+	var data []byte
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+		data, retryable, err = d.get(fileURL, cached)
+		if err == nil || !retryable || attempt >= d.settings.Retries {
+			break
+		}
+		if waitErr := d.wait(d.backoff << attempt); waitErr != nil {
+			return nil, fmt.Errorf("download of %s canceled: %w", fileURL.Redacted(), waitErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// wait sleeps for the delay unless the run context is done before.
+func (d *Downloader) wait(delay time.Duration) error {
+	ctx := d.settings.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// get performs a single download attempt, which is revalidated against the cached entry if there is one.
+func (d *Downloader) get(fileURL *url.URL, cached *entry) ([]byte, bool, error) {
+	ctx, cancel := d.settings.attemptContext()
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL.String(), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create HTTP GET request for %s: %w", fileURL.Redacted(), err)
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, true, fmt.Errorf("HTTP GET request failed for %s: %w", fileURL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return cached.data, false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		retryable := resp.StatusCode >= http.StatusInternalServerError ||
+			resp.StatusCode == http.StatusTooManyRequests
+		return nil, retryable, fmt.Errorf(
+			"received status code %d for GET request to %s: %w",
+			resp.StatusCode,
+			fileURL.Redacted(),
+			errBadHTTPStatus,
+		)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read response body from %s: %w", fileURL.Redacted(), err)
+	}
+
+	if d.cache != nil {
+		// The cache only speeds up later runs, so failing to write it does not fail the download.
+		_ = d.cache.store(fileURL, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), data)
+	}
+	return data, false, nil
+}
@@ -3,6 +3,7 @@ package filesystem
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -35,3 +36,26 @@ func (u *Helper) ReadFile(path string) ([]byte, error) {
 	}
 	return fileContent, nil
 }
+
+// CopyFile copies the content of the source file to the target file, which is created with the permissions or
+// truncated if it exists.
+func CopyFile(source, target string, perm os.FileMode) error {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", source, err)
+	}
+	defer sourceFile.Close()
+
+	targetFile, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", target, err)
+	}
+	if _, err = io.Copy(targetFile, sourceFile); err != nil {
+		_ = targetFile.Close()
+		return fmt.Errorf("failed to write file %s: %w", target, err)
+	}
+	if err = targetFile.Close(); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", target, err)
+	}
+	return nil
+}
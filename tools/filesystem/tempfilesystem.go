@@ -1,34 +1,54 @@
 package filesystem
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"os"
-	"time"
-)
 
-const httpGetTimeout = 20 * time.Second
+	"github.com/kyma-project/modulectl/tools/download"
+)
 
-var errBadHTTPStatus = errors.New("bad http status")
+type Downloader interface {
+	Download(url *url.URL) ([]byte, error)
+}
 
 type TempFileSystem struct {
-	files []*os.File
+	files      []*os.File
+	downloader Downloader
+	dir        string
 }
 
 func NewTempFileSystem() *TempFileSystem {
-	return &TempFileSystem{files: []*os.File{}}
+	return &TempFileSystem{files: []*os.File{}, downloader: download.NewDownloader(http.DefaultClient)}
+}
+
+// WithDownloader sets the downloader used to download files, e.g. to authenticate or cache the downloads.
+func (fs *TempFileSystem) WithDownloader(downloader Downloader) *TempFileSystem {
+	fs.downloader = downloader
+	return fs
+}
+
+// SetDir sets the directory temp files are created in if no directory is passed. An empty dir refers to the default
+// directory for temporary files.
+func (fs *TempFileSystem) SetDir(dir string) {
+	fs.dir = dir
 }
 
 func (fs *TempFileSystem) DownloadTempFile(dir, pattern string, url *url.URL) (string, error) {
-	bytes, err := getBytesFromURL(url)
+	bytes, err := fs.downloader.Download(url)
 	if err != nil {
-		return "", fmt.Errorf("failed to download file from %s: %w", url, err)
+		return "", fmt.Errorf("failed to download file from %s: %w", url.Redacted(), err)
 	}
 
+	return fs.WriteTempFile(dir, pattern, bytes)
+}
+
+// WriteTempFile writes the data to a new temp file, which is removed by RemoveTempFiles.
+func (fs *TempFileSystem) WriteTempFile(dir, pattern string, bytes []byte) (string, error) {
+	if dir == "" {
+		dir = fs.dir
+	}
 	tmpFile, err := os.CreateTemp(dir, pattern)
 	if err != nil {
 		return "", fmt.Errorf("failed to create temp file with pattern %s: %w", pattern, err)
@@ -64,38 +84,3 @@ func (fs *TempFileSystem) RemoveTempFiles() []error {
 	fs.files = []*os.File{}
 	return errs
 }
-
-func getBytesFromURL(url *url.URL) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), httpGetTimeout)
-	defer cancel()
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP GET request for %s: %w", url, err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP GET request failed for %s: %w", url, err)
-	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			err = fmt.Errorf("failed to close response body: %w", closeErr)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(
-			"received status code %d for GET request to %s: %w",
-			resp.StatusCode,
-			url,
-			errBadHTTPStatus,
-		)
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body from %s: %w", url, err)
-	}
-
-	return data, nil
-}
@@ -0,0 +1,277 @@
+package httpauth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFileEnv is the environment variable overriding the location of the per-host credentials config file.
+	ConfigFileEnv = "MODULECTL_AUTH_CONFIG"
+	// TokenEnvPrefix is the prefix of the environment variables providing bearer tokens per host.
+	TokenEnvPrefix = "MODULECTL_AUTH_TOKEN_"
+	// UsernameEnvPrefix is the prefix of the environment variables providing basic auth usernames per host.
+	UsernameEnvPrefix = "MODULECTL_AUTH_USERNAME_"
+	// PasswordEnvPrefix is the prefix of the environment variables providing basic auth passwords per host.
+	PasswordEnvPrefix = "MODULECTL_AUTH_PASSWORD_"
+
+	netrcFileEnv = "NETRC"
+
+	typeBearer = "bearer"
+	typeBasic  = "basic"
+)
+
+var ErrInvalidAuthConfig = errors.New("invalid auth config")
+
+// Credential holds the secret used to authenticate requests to a host. It is never printed.
+type Credential struct {
+	Username string
+	Password string
+	Token    string
+}
+
+func (c Credential) String() string {
+	return "REDACTED"
+}
+
+func (c Credential) GoString() string {
+	return c.String()
+}
+
+func (c Credential) apply(req *http.Request) {
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+		return
+	}
+	req.SetBasicAuth(c.Username, c.Password)
+}
+
+// CredentialLookup looks up the credential of a host, e.g. Credentials.
+type CredentialLookup interface {
+	Lookup(host string) (Credential, bool, error)
+}
+
+// Credentials looks up the credential of a host. The sources are consulted in the following order:
+//   - the MODULECTL_AUTH_TOKEN_<HOST> or MODULECTL_AUTH_USERNAME_<HOST> and MODULECTL_AUTH_PASSWORD_<HOST>
+//     environment variables
+//   - the per-host credentials config file
+//   - the netrc file
+//
+// The config and netrc files are read on the first lookup.
+type Credentials struct {
+	configFile         string
+	configFileRequired bool
+	netrcFile          string
+
+	once  sync.Once
+	hosts map[string]Credential
+	netrc map[string]Credential
+	err   error
+}
+
+// NewCredentials returns Credentials reading the config file from the MODULECTL_AUTH_CONFIG environment variable or
+// modulectl/auth.yaml in the user config directory, and the netrc file from the NETRC environment variable or
+// .netrc in the home directory.
+func NewCredentials() *Credentials {
+	credentials := &Credentials{}
+
+	if configFile := os.Getenv(ConfigFileEnv); configFile != "" {
+		credentials.configFile = configFile
+		credentials.configFileRequired = true
+	} else if configDir, err := os.UserConfigDir(); err == nil {
+		credentials.configFile = filepath.Join(configDir, "modulectl", "auth.yaml")
+	}
+
+	if netrcFile := os.Getenv(netrcFileEnv); netrcFile != "" {
+		credentials.netrcFile = netrcFile
+	} else if homeDir, err := os.UserHomeDir(); err == nil {
+		credentials.netrcFile = filepath.Join(homeDir, ".netrc")
+	}
+
+	return credentials
+}
+
+// WithConfigFile sets the per-host credentials config file, which must exist.
+func (c *Credentials) WithConfigFile(configFile string) *Credentials {
+	c.configFile = configFile
+	c.configFileRequired = true
+	return c
+}
+
+// WithNetrcFile sets the netrc file, which is ignored if it does not exist.
+func (c *Credentials) WithNetrcFile(netrcFile string) *Credentials {
+	c.netrcFile = netrcFile
+	return c
+}
+
+// Lookup returns the credential for the host, which may include a port. Credentials configured for a host without a
+// port apply to all ports of the host.
+func (c *Credentials) Lookup(host string) (Credential, bool, error) {
+	if credential, found := lookupEnv(host); found {
+		return credential, true, nil
+	}
+
+	c.once.Do(c.load)
+	if c.err != nil {
+		return Credential{}, false, c.err
+	}
+
+	for _, candidate := range hostCandidates(host) {
+		if credential, found := c.hosts[candidate]; found {
+			return credential, true, nil
+		}
+	}
+	for _, candidate := range hostCandidates(host) {
+		if credential, found := c.netrc[candidate]; found {
+			return credential, true, nil
+		}
+	}
+	if credential, found := c.netrc[""]; found {
+		return credential, true, nil
+	}
+	return Credential{}, false, nil
+}
+
+func (c *Credentials) load() {
+	if c.hosts, c.err = readConfigFile(c.configFile, c.configFileRequired); c.err != nil {
+		return
+	}
+	c.netrc, c.err = readNetrcFile(c.netrcFile)
+}
+
+func hostCandidates(host string) []string {
+	if hostname, _, found := strings.Cut(host, ":"); found && !strings.Contains(host, "]") {
+		return []string{host, hostname}
+	}
+	return []string{host}
+}
+
+// lookupEnv reads the credential of the host from the environment. The host is upper-cased and all characters other
+// than letters and digits are replaced by underscores, e.g. MODULECTL_AUTH_TOKEN_RAW_GITHUBUSERCONTENT_COM.
+func lookupEnv(host string) (Credential, bool) {
+	for _, candidate := range hostCandidates(host) {
+		suffix := envSuffix(candidate)
+		if token := os.Getenv(TokenEnvPrefix + suffix); token != "" {
+			return Credential{Token: token}, true
+		}
+		username := os.Getenv(UsernameEnvPrefix + suffix)
+		password := os.Getenv(PasswordEnvPrefix + suffix)
+		if username != "" || password != "" {
+			return Credential{Username: username, Password: password}, true
+		}
+	}
+	return Credential{}, false
+}
+
+func envSuffix(host string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, host)
+}
+
+type config struct {
+	Hosts []hostConfig `yaml:"hosts"`
+}
+
+type hostConfig struct {
+	Host        string `yaml:"host"`
+	Type        string `yaml:"type"`
+	Token       string `yaml:"token"`
+	TokenEnv    string `yaml:"tokenEnv"`
+	Username    string `yaml:"username"`
+	Password    string `yaml:"password"`
+	PasswordEnv string `yaml:"passwordEnv"`
+}
+
+func readConfigFile(configFile string, required bool) (map[string]Credential, error) {
+	if configFile == "" {
+		return map[string]Credential{}, nil
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return map[string]Credential{}, nil
+		}
+		return nil, fmt.Errorf("failed to read auth config file %s: %w", configFile, err)
+	}
+
+	// The YAML error is not wrapped, as it may quote parts of the secrets.
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse auth config file %s: %w", configFile, ErrInvalidAuthConfig)
+	}
+
+	hosts := make(map[string]Credential, len(cfg.Hosts))
+	for index, host := range cfg.Hosts {
+		credential, err := host.credential()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse auth config file %s: hosts[%d]: %w", configFile, index, err)
+		}
+		if _, exists := hosts[host.Host]; exists {
+			return nil, fmt.Errorf("failed to parse auth config file %s: hosts[%d]: duplicate host %q: %w",
+				configFile, index, host.Host, ErrInvalidAuthConfig)
+		}
+		hosts[host.Host] = credential
+	}
+	return hosts, nil
+}
+
+func (h hostConfig) credential() (Credential, error) {
+	if h.Host == "" {
+		return Credential{}, fmt.Errorf("host must not be empty: %w", ErrInvalidAuthConfig)
+	}
+
+	switch h.Type {
+	case typeBearer:
+		token, err := secret(h.Token, h.TokenEnv, "token")
+		if err != nil {
+			return Credential{}, err
+		}
+		return Credential{Token: token}, nil
+	case typeBasic:
+		if h.Username == "" {
+			return Credential{}, fmt.Errorf("username must not be empty: %w", ErrInvalidAuthConfig)
+		}
+		password, err := secret(h.Password, h.PasswordEnv, "password")
+		if err != nil {
+			return Credential{}, err
+		}
+		return Credential{Username: h.Username, Password: password}, nil
+	default:
+		return Credential{}, fmt.Errorf("type %q must be one of %q or %q: %w", h.Type, typeBearer, typeBasic,
+			ErrInvalidAuthConfig)
+	}
+}
+
+// secret returns the value if set, otherwise the value of the environment variable named by env.
+func secret(value, env, name string) (string, error) {
+	if value != "" && env != "" {
+		return "", fmt.Errorf("only one of %s and %sEnv may be set: %w", name, name, ErrInvalidAuthConfig)
+	}
+	if env != "" {
+		value = os.Getenv(env)
+		if value == "" {
+			return "", fmt.Errorf("environment variable %s referenced by %sEnv is not set: %w", env, name,
+				ErrInvalidAuthConfig)
+		}
+	}
+	if value == "" {
+		return "", fmt.Errorf("%s must not be empty: %w", name, ErrInvalidAuthConfig)
+	}
+	return value, nil
+}
@@ -0,0 +1,206 @@
+package httpauth_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/tools/httpauth"
+)
+
+func Test_Lookup_ReturnsTokenFromEnv(t *testing.T) {
+	t.Setenv("MODULECTL_AUTH_TOKEN_RAW_GITHUBUSERCONTENT_COM", "env-token")
+	credentials := newCredentials(t, "", "")
+
+	credential, found, err := credentials.Lookup("raw.githubusercontent.com")
+
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "env-token", credential.Token)
+}
+
+func Test_Lookup_ReturnsBasicAuthFromEnv(t *testing.T) {
+	t.Setenv("MODULECTL_AUTH_USERNAME_ARTIFACTS_EXAMPLE_COM", "ci")
+	t.Setenv("MODULECTL_AUTH_PASSWORD_ARTIFACTS_EXAMPLE_COM", "env-password")
+	credentials := newCredentials(t, "", "")
+
+	credential, found, err := credentials.Lookup("artifacts.example.com:8443")
+
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "ci", credential.Username)
+	assert.Equal(t, "env-password", credential.Password)
+}
+
+func Test_Lookup_ReturnsCredentialsFromConfigFile(t *testing.T) {
+	t.Setenv("ARTIFACTS_PASSWORD", "config-password")
+	configFile := writeFile(t, "auth.yaml", `hosts:
+  - host: raw.githubusercontent.com
+    type: bearer
+    token: config-token
+  - host: artifacts.example.com
+    type: basic
+    username: ci
+    passwordEnv: ARTIFACTS_PASSWORD
+`)
+	credentials := newCredentials(t, configFile, "")
+
+	credential, found, err := credentials.Lookup("raw.githubusercontent.com")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "config-token", credential.Token)
+
+	credential, found, err = credentials.Lookup("artifacts.example.com")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "ci", credential.Username)
+	assert.Equal(t, "config-password", credential.Password)
+
+	_, found, err = credentials.Lookup("example.com")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func Test_Lookup_ReturnsCredentialsFromNetrcFile(t *testing.T) {
+	netrcFile := writeFile(t, ".netrc", `# private artifacts
+machine artifacts.example.com login ci password netrc-password
+machine other.example.com
+  login other
+  password other-password
+default login anonymous password default-password
+`)
+	credentials := newCredentials(t, "", netrcFile)
+
+	credential, found, err := credentials.Lookup("artifacts.example.com")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "ci", credential.Username)
+	assert.Equal(t, "netrc-password", credential.Password)
+
+	credential, found, err = credentials.Lookup("other.example.com")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "other", credential.Username)
+
+	credential, found, err = credentials.Lookup("unknown.example.com")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "anonymous", credential.Username)
+}
+
+func Test_Lookup_PrefersEnvOverConfigFileOverNetrcFile(t *testing.T) {
+	configFile := writeFile(t, "auth.yaml", `hosts:
+  - host: artifacts.example.com
+    type: bearer
+    token: config-token
+`)
+	netrcFile := writeFile(t, ".netrc", "machine artifacts.example.com login ci password netrc-password\n")
+	credentials := newCredentials(t, configFile, netrcFile)
+
+	credential, _, err := credentials.Lookup("artifacts.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "config-token", credential.Token)
+
+	t.Setenv("MODULECTL_AUTH_TOKEN_ARTIFACTS_EXAMPLE_COM", "env-token")
+	credential, _, err = credentials.Lookup("artifacts.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "env-token", credential.Token)
+}
+
+func Test_Lookup_ReturnsError_WhenConfigFileIsMissing(t *testing.T) {
+	credentials := newCredentials(t, filepath.Join(t.TempDir(), "auth.yaml"), "")
+
+	_, _, err := credentials.Lookup("example.com")
+
+	require.ErrorContains(t, err, "failed to read auth config file")
+}
+
+func Test_Lookup_ReturnsError_WithoutSecret_WhenConfigFileIsInvalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        string
+		expectedError string
+	}{
+		{
+			name:          "malformed",
+			config:        "hosts: super-secret-token\n",
+			expectedError: "failed to parse auth config file",
+		},
+		{
+			name: "unknown type",
+			config: `hosts:
+  - host: example.com
+    type: digest
+    token: super-secret-token
+`,
+			expectedError: `hosts[0]: type "digest" must be one of "bearer" or "basic"`,
+		},
+		{
+			name: "missing env",
+			config: `hosts:
+  - host: example.com
+    type: bearer
+    tokenEnv: MODULECTL_TEST_UNSET_TOKEN
+`,
+			expectedError: "environment variable MODULECTL_TEST_UNSET_TOKEN referenced by tokenEnv is not set",
+		},
+		{
+			name: "duplicate host",
+			config: `hosts:
+  - host: example.com
+    type: bearer
+    token: super-secret-token
+  - host: example.com
+    type: basic
+    username: ci
+    password: super-secret-token
+`,
+			expectedError: `hosts[1]: duplicate host "example.com"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			credentials := newCredentials(t, writeFile(t, "auth.yaml", test.config), "")
+
+			_, _, err := credentials.Lookup("example.com")
+
+			require.ErrorIs(t, err, httpauth.ErrInvalidAuthConfig)
+			require.ErrorContains(t, err, test.expectedError)
+			assert.NotContains(t, err.Error(), "super-secret-token")
+		})
+	}
+}
+
+func Test_Credential_IsRedacted_WhenPrinted(t *testing.T) {
+	credential := httpauth.Credential{Username: "ci", Password: "super-secret-password", Token: "super-secret-token"}
+
+	assert.Equal(t, "REDACTED", credential.String())
+	assert.Equal(t, "REDACTED", credential.GoString())
+}
+
+func newCredentials(t *testing.T, configFile, netrcFile string) *httpauth.Credentials {
+	t.Helper()
+	t.Setenv(httpauth.ConfigFileEnv, "")
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), ".netrc"))
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	credentials := httpauth.NewCredentials()
+	if configFile != "" {
+		credentials = credentials.WithConfigFile(configFile)
+	}
+	if netrcFile != "" {
+		credentials = credentials.WithNetrcFile(netrcFile)
+	}
+	return credentials
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+	return filePath
+}
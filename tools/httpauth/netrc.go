@@ -0,0 +1,79 @@
+package httpauth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// readNetrcFile reads the machine and default entries of a netrc file. The default entry is stored with an empty
+// host. A missing file results in no credentials.
+func readNetrcFile(netrcFile string) (map[string]Credential, error) {
+	credentials := map[string]Credential{}
+	if netrcFile == "" {
+		return credentials, nil
+	}
+
+	data, err := os.ReadFile(netrcFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return credentials, nil
+		}
+		return nil, fmt.Errorf("failed to read netrc file %s: %w", netrcFile, err)
+	}
+
+	var host string
+	var credential Credential
+	inEntry := false
+	flush := func() {
+		if inEntry && credential.Username != "" {
+			if _, exists := credentials[host]; !exists {
+				credentials[host] = credential
+			}
+		}
+	}
+
+	lines := strings.Split(string(data), "\n")
+	for lineIndex := 0; lineIndex < len(lines); lineIndex++ {
+		fields := strings.Fields(lines[lineIndex])
+		for i := 0; i < len(fields); i++ {
+			if strings.HasPrefix(fields[i], "#") {
+				break
+			}
+
+			switch fields[i] {
+			case "machine", "default":
+				flush()
+				inEntry = true
+				credential = Credential{}
+				host = ""
+				if fields[i] == "machine" && i+1 < len(fields) {
+					i++
+					host = fields[i]
+				}
+			case "login":
+				if i+1 < len(fields) {
+					i++
+					credential.Username = fields[i]
+				}
+			case "password":
+				if i+1 < len(fields) {
+					i++
+					credential.Password = fields[i]
+				}
+			case "account":
+				i++
+			case "macdef":
+				// macro definitions end with an empty line
+				for lineIndex+1 < len(lines) && strings.TrimSpace(lines[lineIndex+1]) != "" {
+					lineIndex++
+				}
+				i = len(fields)
+			}
+		}
+	}
+	flush()
+
+	return credentials, nil
+}
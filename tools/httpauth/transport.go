@@ -0,0 +1,44 @@
+package httpauth
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Transport adds the credential of the request host to HTTPS requests that do not carry an Authorization header
+// yet. As credentials are looked up per request, redirects to other hosts never receive them.
+type Transport struct {
+	base        http.RoundTripper
+	credentials *Credentials
+}
+
+func NewTransport(base http.RoundTripper, credentials *Credentials) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:        base,
+		credentials: credentials,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.credentials == nil || req.URL.Scheme != "https" || req.Header.Get("Authorization") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	credential, found, err := t.credentials.Lookup(req.URL.Host)
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("failed to look up credentials for %s: %w", req.URL.Host, err)
+	}
+	if !found {
+		return t.base.RoundTrip(req)
+	}
+
+	authenticatedReq := req.Clone(req.Context())
+	credential.apply(authenticatedReq)
+	return t.base.RoundTrip(authenticatedReq)
+}
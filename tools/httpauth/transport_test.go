@@ -0,0 +1,128 @@
+package httpauth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/tools/httpauth"
+)
+
+func Test_Transport_AddsCredentialOfRequestHost(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	credentials := newCredentials(t, writeFile(t, "auth.yaml", `hosts:
+  - host: `+serverURL.Hostname()+`
+    type: bearer
+    token: secret-token
+`), "")
+	client := &http.Client{Transport: httpauth.NewTransport(server.Client().Transport, credentials)}
+
+	get(t, client, server.URL)
+
+	assert.Equal(t, "Bearer secret-token", authorization)
+}
+
+func Test_Transport_KeepsExistingAuthorization(t *testing.T) {
+	var username, password string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		username, password, _ = r.BasicAuth()
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	t.Setenv("MODULECTL_AUTH_TOKEN_127_0_0_1", "secret-token")
+	credentials := newCredentials(t, "", "")
+	client := &http.Client{Transport: httpauth.NewTransport(server.Client().Transport, credentials)}
+
+	serverURL.User = url.UserPassword("user", "url-password")
+	get(t, client, serverURL.String())
+
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "url-password", password)
+}
+
+func Test_Transport_DoesNotAddCredentialToPlainHTTPRequests(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	t.Setenv("MODULECTL_AUTH_TOKEN_127_0_0_1", "secret-token")
+	credentials := newCredentials(t, "", "")
+	client := &http.Client{Transport: httpauth.NewTransport(nil, credentials)}
+
+	get(t, client, server.URL)
+
+	assert.Empty(t, authorization)
+}
+
+func Test_Transport_DoesNotForwardCredentialOnRedirectToOtherHost(t *testing.T) {
+	var redirectedAuthorization string
+	target := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		redirectedAuthorization = r.Header.Get("Authorization")
+	}))
+	defer target.Close()
+	targetURL, err := url.Parse(target.URL)
+	require.NoError(t, err)
+	targetURL.Host = "localhost:" + targetURL.Port()
+
+	var authorization string
+	origin := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		http.Redirect(w, r, targetURL.String(), http.StatusFound)
+	}))
+	defer origin.Close()
+
+	t.Setenv("MODULECTL_AUTH_TOKEN_127_0_0_1", "secret-token")
+	credentials := newCredentials(t, "", "")
+	baseTransport, ok := origin.Client().Transport.(*http.Transport)
+	require.True(t, ok)
+	baseTransport.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec // test servers use self-signed certificates
+	client := &http.Client{Transport: httpauth.NewTransport(baseTransport, credentials)}
+
+	get(t, client, origin.URL)
+
+	assert.Equal(t, "Bearer secret-token", authorization)
+	assert.Empty(t, redirectedAuthorization)
+}
+
+func Test_Transport_ReturnsError_WithoutSecret_WhenCredentialsAreInvalid(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	credentials := newCredentials(t, writeFile(t, "auth.yaml", "hosts: super-secret-token\n"), "")
+	client := &http.Client{Transport: httpauth.NewTransport(server.Client().Transport, credentials)}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	require.ErrorIs(t, err, httpauth.ErrInvalidAuthConfig)
+	assert.NotContains(t, err.Error(), "super-secret-token")
+}
+
+func get(t *testing.T, client *http.Client, rawURL string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, rawURL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}